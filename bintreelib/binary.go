@@ -0,0 +1,82 @@
+package bintreelib
+
+import (
+	"fmt"
+
+	"github.com/pluckynumbat/go-tree/internal/binfmt"
+)
+
+var codecNilError = fmt.Errorf("the value codec is nil")
+
+// ValueCodec turns the values of a tree into bytes and back, for the binary encoding
+// It is the shared binfmt codec under the package's own name, so both tree packages accept the same codecs
+type ValueCodec[T any] = binfmt.Codec[T]
+
+// MarshalBinary is BinaryTree's implementation of the encoding.BinaryMarshaler interface, which also makes encoding/gob use it
+// The compact format has a magic and version header, a varint shape bitmap, length prefixed values, and a CRC-32 checksum
+// Booleans, integers, floats and strings are written directly, types with their own MarshalBinary and UnmarshalBinary use those,
+// and any other values are written as JSON. MarshalBinaryWithCodec takes a codec for the values instead
+// encoding/gob only uses it on a tree it can take the address of, so a struct holding a BinaryTree by value
+// has to be passed to gob by pointer (or hold a *BinaryTree instead)
+func (bt *BinaryTree[T]) MarshalBinary() ([]byte, error) {
+	return bt.MarshalBinaryWithCodec(binfmt.DefaultCodec[T]())
+}
+
+// MarshalBinaryWithCodec writes the binary tree in the same compact format as MarshalBinary, using the given codec for the values
+func (bt *BinaryTree[T]) MarshalBinaryWithCodec(codec ValueCodec[T]) ([]byte, error) {
+	if bt.IsNil() {
+		return nil, treeNilError
+	}
+
+	if codec == nil {
+		return nil, codecNilError
+	}
+
+	data, err := binfmt.Encode(binfmt.BinaryTree, bt.root, (*Node[T]).children, func(node *Node[T]) T { return node.data }, nil, codec)
+	if err != nil {
+		return nil, fmt.Errorf("method MarshalBinary() failed with error: %v", err)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary is BinaryTree's implementation of the encoding.BinaryUnmarshaler interface
+// It replaces the contents of the tree with the one written by MarshalBinary, keeping its exact shape,
+// and leaves the tree as it was if the data is corrupted or was written by some other tree type
+func (bt *BinaryTree[T]) UnmarshalBinary(data []byte) error {
+	return bt.UnmarshalBinaryWithCodec(data, binfmt.DefaultCodec[T]())
+}
+
+// UnmarshalBinaryWithCodec reads a binary tree written by MarshalBinaryWithCodec, using the same codec for the values
+func (bt *BinaryTree[T]) UnmarshalBinaryWithCodec(data []byte, codec ValueCodec[T]) error {
+	if bt.IsNil() {
+		return treeNilError
+	}
+
+	if codec == nil {
+		return codecNilError
+	}
+
+	decoded, err := binfmt.Decode(binfmt.BinaryTree, data, codec)
+	if err != nil {
+		return fmt.Errorf("method UnmarshalBinary() failed with error: %v", err)
+	}
+
+	shaped, err := newShapedTree(recurseFromDecoded(decoded, nil))
+	if err != nil {
+		return fmt.Errorf("method UnmarshalBinary() failed with error: %v", err)
+	}
+
+	*bt = *shaped
+	return nil
+}
+
+func recurseFromDecoded[T comparable](decoded *binfmt.Node[T], parent *Node[T]) *Node[T] {
+	if decoded == nil {
+		return nil
+	}
+
+	node := &Node[T]{data: decoded.Value, parent: parent}
+	node.left = recurseFromDecoded(decoded.Left, node)
+	node.right = recurseFromDecoded(decoded.Right, node)
+	return node
+}
@@ -0,0 +1,218 @@
+package bintreelib
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+// decimalCodec writes integers as decimal text, to check that MarshalBinaryWithCodec uses the codec it is given
+type decimalCodec struct{}
+
+func (decimalCodec) EncodeValue(value int) ([]byte, error) {
+	return []byte(strconv.Itoa(value)), nil
+}
+
+func (decimalCodec) DecodeValue(data []byte) (int, error) {
+	return strconv.Atoi(string(data))
+}
+
+func TestMarshalBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty tree", "[]"},
+		{"single node", "[1]"},
+		{"complete tree", "[1,2,3,4,5,6]"},
+		{"gaps", "[1,2,3,null,4,5]"},
+		{"right chain", "[1,null,2,null,3]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bt, err := Deserialize[int](test.data)
+			if err != nil {
+				t.Fatalf("Deserialize() failed with error: %v", err)
+			}
+
+			for _, codec := range []ValueCodec[int]{nil, decimalCodec{}} {
+				var data []byte
+				readBack := &BinaryTree[int]{}
+				if codec == nil {
+					data, err = bt.MarshalBinary()
+					if err == nil {
+						err = readBack.UnmarshalBinary(data)
+					}
+				} else {
+					data, err = bt.MarshalBinaryWithCodec(codec)
+					if err == nil {
+						err = readBack.UnmarshalBinaryWithCodec(data, codec)
+					}
+				}
+				if err != nil {
+					t.Fatalf("binary round trip failed with error: %v", err)
+				}
+
+				got, err2 := readBack.Serialize()
+				if err2 != nil {
+					t.Fatalf("Serialize() failed with error: %v", err2)
+				}
+				if got != test.data {
+					t.Errorf("UnmarshalBinary() returned incorrect results, want: %v, got: %v", test.data, got)
+				}
+
+				checkNodeLinks(t, readBack)
+			}
+		})
+	}
+}
+
+func TestGob(t *testing.T) {
+	bt, err := ConstructFromValues("a", "b", "c", "d")
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	buffer := &bytes.Buffer{}
+	err = gob.NewEncoder(buffer).Encode(bt)
+	if err != nil {
+		t.Fatalf("gob Encode() failed with error: %v", err)
+	}
+
+	readBack := &BinaryTree[string]{}
+	err = gob.NewDecoder(buffer).Decode(readBack)
+	if err != nil {
+		t.Fatalf("gob Decode() failed with error: %v", err)
+	}
+
+	equal, err := readBack.Equal(bt)
+	if err != nil {
+		t.Fatalf("Equal() failed with error: %v", err)
+	}
+	if !equal {
+		t.Errorf("gob did not give back the original tree, got: %v", readBack)
+	}
+
+	// the decoded tree is complete, so it keeps growing breadth first
+	err = readBack.AddNodeBFS("e")
+	if err != nil {
+		t.Fatalf("AddNodeBFS() failed with error: %v", err)
+	}
+	got, _ := readBack.TraverseBFS()
+	if got != "-a--b--c--d--e-" {
+		t.Errorf("AddNodeBFS() after gob returned incorrect results, want: %v, got: %v", "-a--b--c--d--e-", got)
+	}
+
+	// a tree held by value inside a struct, which gob can only reach when the struct is passed by pointer
+	type document struct {
+		Name string
+		Tree BinaryTree[string]
+	}
+
+	buffer.Reset()
+	err = gob.NewEncoder(buffer).Encode(&document{"letters", *bt})
+	if err != nil {
+		t.Fatalf("gob Encode() of a tree held by value failed with error: %v", err)
+	}
+
+	var doc document
+	err = gob.NewDecoder(buffer).Decode(&doc)
+	if err != nil {
+		t.Fatalf("gob Decode() failed with error: %v", err)
+	}
+
+	equal, err = doc.Tree.Equal(bt)
+	if err != nil {
+		t.Fatalf("Equal() failed with error: %v", err)
+	}
+	if doc.Name != "letters" || !equal {
+		t.Errorf("gob did not give back the original document, got: %v", doc)
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	var nilTree *BinaryTree[int]
+	_, err := nilTree.MarshalBinary()
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("MarshalBinary() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	err = nilTree.UnmarshalBinary(nil)
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("UnmarshalBinary() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	bt, err := ConstructFromValues(7, 8)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	_, err = bt.MarshalBinaryWithCodec(nil)
+	if !errors.Is(err, codecNilError) {
+		t.Errorf("MarshalBinaryWithCodec() without a codec should have failed with error: %v, got: %v", codecNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	data, err := bt.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() failed with error: %v", err)
+	}
+
+	corrupted := bytes.Clone(data)
+	corrupted[len(corrupted)/2] ^= 0xff
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"no data", nil},
+		{"corrupted", corrupted},
+		{"json", []byte(`{"value":1}`)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			readBack, err2 := ConstructFromValues(1, 2, 3)
+			if err2 != nil {
+				t.Fatalf("ConstructFromValues() failed with error: %v", err2)
+			}
+
+			err2 = readBack.UnmarshalBinary(test.data)
+			if err2 == nil {
+				t.Fatalf("UnmarshalBinary() should have failed")
+			}
+			fmt.Println(err2)
+
+			// a failed read leaves the tree as it was
+			got, _ := readBack.TraverseBFS()
+			if got != "-1--2--3-" {
+				t.Errorf("UnmarshalBinary() changed the tree after failing, got: %v", got)
+			}
+		})
+	}
+
+	// the text codec writes values the default codec can't read
+	bt, err = ConstructFromValues(123, 456)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+	data, err = bt.MarshalBinaryWithCodec(decimalCodec{})
+	if err != nil {
+		t.Fatalf("MarshalBinaryWithCodec() failed with error: %v", err)
+	}
+	err = (&BinaryTree[int]{}).UnmarshalBinary(data)
+	if err == nil {
+		t.Errorf("UnmarshalBinary() with the wrong codec should have failed")
+	} else {
+		fmt.Println(err)
+	}
+}
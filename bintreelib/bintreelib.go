@@ -3,6 +3,7 @@ package bintreelib
 
 import (
 	"fmt"
+	"math/bits"
 
 	"github.com/pluckynumbat/go-quez/sgquezlib"
 	"github.com/pluckynumbat/go-stax/sgstaxlib"
@@ -11,24 +12,28 @@ import (
 var nodeNilError error = fmt.Errorf("the node is nil")
 var treeNilError = fmt.Errorf("the binary tree is nil")
 var treeEmptyError = fmt.Errorf("the binary tree is empty")
+var treeIncompleteError = fmt.Errorf("the binary tree is not complete")
 
-type Node struct {
-	data   string
-	parent *Node
-	left   *Node
-	right  *Node
+const invalidCount = -1
+
+// Node is the basic unit of the binary tree, and contains data which can be of any comparable type
+type Node[T comparable] struct {
+	data   T
+	parent *Node[T]
+	left   *Node[T]
+	right  *Node[T]
 }
 
 // Node's implementation of the fmt.Stringer interface
-func (node *Node) String() string {
+func (node *Node[T]) String() string {
 	if node == nil {
 		return "nil"
 	}
-	return node.data
+	return fmt.Sprintf("%v", node.data)
 }
 
 // Parent is used to get a pointer to the parent node of a given node
-func (node *Node) Parent() (*Node, error) {
+func (node *Node[T]) Parent() (*Node[T], error) {
 	if node == nil {
 		return nil, nodeNilError
 	}
@@ -36,7 +41,7 @@ func (node *Node) Parent() (*Node, error) {
 }
 
 // LeftChild is used to get a pointer to the left child of a given node
-func (node *Node) LeftChild() (*Node, error) {
+func (node *Node[T]) LeftChild() (*Node[T], error) {
 	if node == nil {
 		return nil, nodeNilError
 	}
@@ -44,30 +49,41 @@ func (node *Node) LeftChild() (*Node, error) {
 }
 
 // RightChild is used to get a pointer to the right child of a given node
-func (node *Node) RightChild() (*Node, error) {
+func (node *Node[T]) RightChild() (*Node[T], error) {
 	if node == nil {
 		return nil, nodeNilError
 	}
 	return node.right, nil
 }
 
-type BinaryTree struct {
-	root     *Node
-	lastLeaf *Node
+// BinaryTree is a binary tree of comparable values, where nodes are added level by level, from left to right
+// A tree built by adding nodes is always complete, but one rebuilt from its traversals can have any shape
+type BinaryTree[T comparable] struct {
+	root     *Node[T]
+	lastLeaf *Node[T] // the last node in breadth first order
+	count    int
+
+	incomplete bool // set when the tree is not complete, so positions can no longer be found from the count
 }
 
+// StringNode is the string based node, kept for callers of the original non generic API
+type StringNode = Node[string]
+
+// StringBinaryTree is the string based binary tree, kept for callers of the original non generic API
+type StringBinaryTree = BinaryTree[string]
+
 // IsNil tells you if this pointer to the Binary Tree is nil
-func (bt *BinaryTree) IsNil() bool {
+func (bt *BinaryTree[T]) IsNil() bool {
 	return bt == nil
 }
 
 // IsEmpty checks whether a Binary Tree is empty
-func (bt *BinaryTree) IsEmpty() bool {
+func (bt *BinaryTree[T]) IsEmpty() bool {
 	return bt.IsNil() || bt.root == nil
 }
 
 // Root returns a pointer to the root of the Binary Tree
-func (bt *BinaryTree) Root() *Node {
+func (bt *BinaryTree[T]) Root() *Node[T] {
 	if bt.IsNil() {
 		return nil
 	}
@@ -75,72 +91,85 @@ func (bt *BinaryTree) Root() *Node {
 }
 
 // LastLeaf returns a pointer to the last leaf of the Binary Tree
-func (bt *BinaryTree) LastLeaf() *Node {
+func (bt *BinaryTree[T]) LastLeaf() *Node[T] {
 	if bt.IsNil() {
 		return nil
 	}
 	return bt.lastLeaf
 }
 
-// AddNodeBFS finds the next free position using a breadth first search and adds a node there
-func (bt *BinaryTree) AddNodeBFS(val string) error {
+// Count returns the number of nodes in the Binary Tree
+func (bt *BinaryTree[T]) Count() (int, error) {
+	if bt.IsNil() {
+		return invalidCount, treeNilError
+	}
+	return bt.count, nil
+}
+
+// nodeAt returns the node at the given position, where positions are numbered from 1 (the root) in breadth first order
+// In a complete tree, the bits of the position after its leading 1 spell out the path from the root (0 is left, 1 is right),
+// so this runs in O(log n). It returns nil if there is no node at that position
+func (bt *BinaryTree[T]) nodeAt(position int) *Node[T] {
+	if position < 1 {
+		return nil
+	}
+
+	runner := bt.root
+	for bit := bits.Len(uint(position)) - 2; bit >= 0 && runner != nil; bit-- {
+		if position&(1<<bit) == 0 {
+			runner = runner.left
+		} else {
+			runner = runner.right
+		}
+	}
+	return runner
+}
+
+// AddNodeBFS adds a node at the next free position in breadth first order (keeping the tree complete)
+// The position of the new node is found directly from the node count, in O(log n)
+// In a tree that is not complete, the first node in breadth first order with a free child slot is found with a BFS instead
+func (bt *BinaryTree[T]) AddNodeBFS(val T) error {
 	if bt == nil {
 		return treeNilError
 	}
 
-	node := &Node{val, nil, nil, nil}
+	if bt.incomplete {
+		return bt.addNodeFirstFree(val)
+	}
+
+	node := &Node[T]{val, nil, nil, nil}
 
 	if bt.root == nil {
 		//insert as root
 		bt.root = node
 		bt.lastLeaf = bt.root
+		bt.count = 1
 		return nil
 	}
 
-	queue := &sgquezlib.SemiGenericQueue[*Node]{}
-	err := queue.Enqueue(bt.root)
-	if err != nil {
-		return fmt.Errorf("add node (BFS) failed with error: %v", err)
+	position := bt.count + 1
+	parent := bt.nodeAt(position / 2)
+	if parent == nil {
+		return fmt.Errorf("add node (BFS) failed with error: no node at position %v", position/2)
 	}
 
-	for !queue.IsEmpty() {
-		runner, err2 := queue.Dequeue()
-		if err2 != nil {
-			return fmt.Errorf("add node (BFS) failed with error: %v", err2)
-		}
-
-		if runner.left == nil {
-			//insert as left child
-			node.parent = runner
-			runner.left = node
-			bt.lastLeaf = runner.left
-			return nil
-		} else {
-			err2 = queue.Enqueue(runner.left)
-			if err2 != nil {
-				return fmt.Errorf("add node (BFS) failed with error: %v", err2)
-			}
-		}
-
-		if runner.right == nil {
-			//insert as right child
-			node.parent = runner
-			runner.right = node
-			bt.lastLeaf = runner.right
-			return nil
-		} else {
-			err2 = queue.Enqueue(runner.right)
-			if err2 != nil {
-				return fmt.Errorf("add node (BFS) failed with error: %v", err2)
-			}
-		}
+	node.parent = parent
+	if position%2 == 0 {
+		//insert as left child
+		parent.left = node
+	} else {
+		//insert as right child
+		parent.right = node
 	}
+
+	bt.lastLeaf = node
+	bt.count += 1
 	return nil
 }
 
 // ConstructFromValues is a helper function to add all values from the given slice to a tree
-func ConstructFromValues(values ...string) (*BinaryTree, error) {
-	binTree := &BinaryTree{}
+func ConstructFromValues[T comparable](values ...T) (*BinaryTree[T], error) {
+	binTree := &BinaryTree[T]{}
 
 	for _, val := range values {
 		err := binTree.AddNodeBFS(val)
@@ -152,7 +181,7 @@ func ConstructFromValues(values ...string) (*BinaryTree, error) {
 }
 
 // TraverseBFS returns a string that represents the traversal order of nodes using Breadth First Search
-func (bt *BinaryTree) TraverseBFS() (string, error) {
+func (bt *BinaryTree[T]) TraverseBFS() (string, error) {
 	if bt.IsNil() {
 		return "", treeNilError
 	}
@@ -163,7 +192,7 @@ func (bt *BinaryTree) TraverseBFS() (string, error) {
 
 	treeStr := ""
 
-	queue := &sgquezlib.SemiGenericQueue[*Node]{}
+	queue := &sgquezlib.SemiGenericQueue[*Node[T]]{}
 	err := queue.Enqueue(bt.root)
 	if err != nil {
 		return "", fmt.Errorf("BFS traversal failed with error: %v", err)
@@ -198,7 +227,7 @@ func (bt *BinaryTree) TraverseBFS() (string, error) {
 // TraverseDFSPreOrderRecursive returns a string that represents the traversal order of nodes using Depth First Search
 // In a pre-order manner (visit a node, then its left subtree, followed by its right subtree)
 // This method uses recursion
-func (bt *BinaryTree) TraverseDFSPreOrderRecursive() (string, error) {
+func (bt *BinaryTree[T]) TraverseDFSPreOrderRecursive() (string, error) {
 	if bt.IsNil() {
 		return "", treeNilError
 	}
@@ -211,7 +240,7 @@ func (bt *BinaryTree) TraverseDFSPreOrderRecursive() (string, error) {
 	return treeStr, nil
 }
 
-func dfsPreOrderRecurse(node *Node) string {
+func dfsPreOrderRecurse[T comparable](node *Node[T]) string {
 	if node == nil {
 		return ""
 	}
@@ -225,7 +254,7 @@ func dfsPreOrderRecurse(node *Node) string {
 // TraverseDFSPreOrderIterative returns a string that represents the traversal order of nodes using Depth First Search
 // In a pre-order manner (visit a node, then its left subtree, followed by its right subtree)
 // This method simulates recursion using the semi generic stack
-func (bt *BinaryTree) TraverseDFSPreOrderIterative() (string, error) {
+func (bt *BinaryTree[T]) TraverseDFSPreOrderIterative() (string, error) {
 	if bt.IsNil() {
 		return "", treeNilError
 	}
@@ -236,7 +265,7 @@ func (bt *BinaryTree) TraverseDFSPreOrderIterative() (string, error) {
 
 	treeStr := ""
 
-	stack := &sgstaxlib.SemiGenericStack[*Node]{}
+	stack := &sgstaxlib.SemiGenericStack[*Node[T]]{}
 	err := stack.Push(bt.root)
 	if err != nil {
 		return "", fmt.Errorf("DFS (pre order) iterative traversal failed with error: %v", err)
@@ -274,7 +303,7 @@ func (bt *BinaryTree) TraverseDFSPreOrderIterative() (string, error) {
 // TraverseDFSInOrderRecursive returns a string that represents the traversal order of nodes using Depth First Search
 // In an in-order manner (visit a node's left subtree, then the node itself, followed by its right subtree)
 // This method uses recursion
-func (bt *BinaryTree) TraverseDFSInOrderRecursive() (string, error) {
+func (bt *BinaryTree[T]) TraverseDFSInOrderRecursive() (string, error) {
 	if bt.IsNil() {
 		return "", treeNilError
 	}
@@ -287,7 +316,7 @@ func (bt *BinaryTree) TraverseDFSInOrderRecursive() (string, error) {
 	return treeStr, nil
 }
 
-func dfsInOrderRecurse(node *Node) string {
+func dfsInOrderRecurse[T comparable](node *Node[T]) string {
 	if node == nil {
 		return ""
 	}
@@ -302,7 +331,7 @@ func dfsInOrderRecurse(node *Node) string {
 // TraverseDFSInOrderIterative returns a string that represents the traversal order of nodes using Depth First Search
 // In an in-order manner (visit a node's left subtree, then the node itself, followed by its right subtree)
 // This method simulates recursion using the semi generic stack
-func (bt *BinaryTree) TraverseDFSInOrderIterative() (string, error) {
+func (bt *BinaryTree[T]) TraverseDFSInOrderIterative() (string, error) {
 	if bt.IsNil() {
 		return "", treeNilError
 	}
@@ -312,7 +341,7 @@ func (bt *BinaryTree) TraverseDFSInOrderIterative() (string, error) {
 	}
 
 	runner := bt.root
-	stack := sgstaxlib.SemiGenericStack[*Node]{}
+	stack := sgstaxlib.SemiGenericStack[*Node[T]]{}
 	treeStr := ""
 
 	for runner != nil || !stack.IsEmpty() {
@@ -324,7 +353,8 @@ func (bt *BinaryTree) TraverseDFSInOrderIterative() (string, error) {
 			runner = runner.left
 
 		} else {
-			runner, err := stack.Pop()
+			var err error
+			runner, err = stack.Pop()
 			if err != nil {
 				return "", fmt.Errorf("DFS (in order) iterative traversal failed with error: %v", err)
 			}
@@ -339,7 +369,7 @@ func (bt *BinaryTree) TraverseDFSInOrderIterative() (string, error) {
 // TraverseDFSPostOrderRecursive returns a string that represents the traversal order of nodes using Depth First Search
 // In a post-order manner (visit a node's left subtree, then the node's left subtree, finally the node itself)
 // This method uses recursion
-func (bt *BinaryTree) TraverseDFSPostOrderRecursive() (string, error) {
+func (bt *BinaryTree[T]) TraverseDFSPostOrderRecursive() (string, error) {
 	if bt.IsNil() {
 		return "", treeNilError
 	}
@@ -352,7 +382,7 @@ func (bt *BinaryTree) TraverseDFSPostOrderRecursive() (string, error) {
 	return treeStr, nil
 }
 
-func dfsPostOrderRecurse(node *Node) string {
+func dfsPostOrderRecurse[T comparable](node *Node[T]) string {
 	if node == nil {
 		return ""
 	}
@@ -367,7 +397,7 @@ func dfsPostOrderRecurse(node *Node) string {
 // TraverseDFSPostOrderIterative returns a string that represents the traversal order of nodes using Depth First Search
 // In a post-order manner (visit a node's left subtree, then the node's left subtree, finally the node itself)
 // This method simulates recursion using the semi generic stack
-func (bt *BinaryTree) TraverseDFSPostOrderIterative() (string, error) {
+func (bt *BinaryTree[T]) TraverseDFSPostOrderIterative() (string, error) {
 	if bt.IsNil() {
 		return "", treeNilError
 	}
@@ -379,9 +409,9 @@ func (bt *BinaryTree) TraverseDFSPostOrderIterative() (string, error) {
 	treeStr := ""
 
 	runner := bt.root
-	var lastVisited *Node
+	var lastVisited *Node[T]
 
-	stack := sgstaxlib.SemiGenericStack[*Node]{}
+	stack := sgstaxlib.SemiGenericStack[*Node[T]]{}
 
 	for runner != nil || !stack.IsEmpty() {
 		if runner != nil {
@@ -412,8 +442,8 @@ func (bt *BinaryTree) TraverseDFSPostOrderIterative() (string, error) {
 	return treeStr, nil
 }
 
-// Contains performs a BFS on the binary tree and tells you if the binary tree contains a node for the input string
-func (bt *BinaryTree) Contains(val string) (bool, error) {
+// Contains performs a BFS on the binary tree and tells you if the binary tree contains a node for the input value
+func (bt *BinaryTree[T]) Contains(val T) (bool, error) {
 	if bt.IsNil() {
 		return false, treeNilError
 	}
@@ -422,7 +452,7 @@ func (bt *BinaryTree) Contains(val string) (bool, error) {
 		return false, treeEmptyError
 	}
 
-	queue := sgquezlib.SemiGenericQueue[*Node]{}
+	queue := sgquezlib.SemiGenericQueue[*Node[T]]{}
 	err := queue.Enqueue(bt.root)
 	if err != nil {
 		return false, fmt.Errorf("method Contains() failed with error: %v", err)
@@ -455,8 +485,11 @@ func (bt *BinaryTree) Contains(val string) (bool, error) {
 	return false, nil
 }
 
-// RemoveValue will remove the first instance of the input value, if it exists in the binary tree
-func (bt *BinaryTree) RemoveValue(val string) error {
+// RemoveValue will remove the first instance (in breadth first order) of the input value, if it exists in the binary tree
+// Finding the value takes a breadth first search, so removal is O(n), only moving the last leaf into its place is O(log n)
+// The value of the last leaf gets moved into the node that held the removed value, and the last leaf's node leaves the tree,
+// so a *Node held from before the call can end up holding a different value, or no longer belong to the tree
+func (bt *BinaryTree[T]) RemoveValue(val T) error {
 	if bt.IsNil() {
 		return treeNilError
 	}
@@ -470,6 +503,8 @@ func (bt *BinaryTree) RemoveValue(val string) error {
 		if bt.root.data == val {
 			bt.root = nil
 			bt.lastLeaf = nil
+			bt.count = 0
+			bt.incomplete = false
 			return nil
 		} else {
 			return fmt.Errorf("the value %v was not found in the binary tree", val)
@@ -477,13 +512,13 @@ func (bt *BinaryTree) RemoveValue(val string) error {
 	}
 
 	// Part 1: check if the value is present and store its node if so
-	queue := sgquezlib.SemiGenericQueue[*Node]{}
+	queue := sgquezlib.SemiGenericQueue[*Node[T]]{}
 	err := queue.Enqueue(bt.root)
 	if err != nil {
 		return fmt.Errorf("method RemoveValue() failed with error: %v", err)
 	}
 
-	var nodeWithValue *Node
+	var nodeWithValue *Node[T]
 	for !queue.IsEmpty() {
 		runner, err2 := queue.Dequeue()
 		if err2 != nil {
@@ -529,45 +564,24 @@ func (bt *BinaryTree) RemoveValue(val string) error {
 		nodeWithValue.data = bt.lastLeaf.data
 	}
 
-	// Part 3: remove the node at last leaf
-	if bt.lastLeaf.parent.left == bt.lastLeaf {
-		bt.lastLeaf.parent.left = nil
+	// Part 3: remove the node at last leaf, cutting all its links so it can't pass for a node of the tree any more
+	removed := bt.lastLeaf
+	if removed.parent.left == removed {
+		removed.parent.left = nil
 	}
-	if bt.lastLeaf.parent.right == bt.lastLeaf {
-		bt.lastLeaf.parent.right = nil
+	if removed.parent.right == removed {
+		removed.parent.right = nil
 	}
+	removed.parent, removed.left, removed.right = nil, nil, nil
 	bt.lastLeaf = nil
 
-	// Part 4: assign a new last leaf node
-	queue = sgquezlib.SemiGenericQueue[*Node]{}
-	err = queue.Enqueue(bt.root)
-	if err != nil {
-		return fmt.Errorf("method RemoveValue() failed with error: %v", err)
+	// Part 4: assign a new last leaf node, which is the node just before the removed one in breadth first order
+	if bt.incomplete {
+		return bt.refreshShape()
 	}
 
-	for !queue.IsEmpty() {
-		runner, err2 := queue.Dequeue()
-		if err2 != nil {
-			return fmt.Errorf("method RemoveValue() failed with error: %v", err2)
-		}
-
-		//update the last leaf pointer
-		bt.lastLeaf = runner
-
-		if runner.left != nil {
-			err2 = queue.Enqueue(runner.left)
-			if err2 != nil {
-				return fmt.Errorf("method RemoveValue() failed with error: %v", err2)
-			}
-		}
-
-		if runner.right != nil {
-			err2 = queue.Enqueue(runner.right)
-			if err2 != nil {
-				return fmt.Errorf("method RemoveValue() failed with error: %v", err2)
-			}
-		}
-	}
+	bt.count -= 1
+	bt.lastLeaf = bt.nodeAt(bt.count)
 
 	// at this point, last leaf should be correctly assigned to the last node in a BFS traversal of the binary tree
 	return nil
@@ -8,14 +8,14 @@ import (
 )
 
 func TestNodeString(t *testing.T) {
-	var n1, n2, n3 *Node
+	var n1, n2, n3 *Node[string]
 
-	n2 = &Node{}
-	n3 = &Node{"a", nil, nil, nil}
+	n2 = &Node[string]{}
+	n3 = &Node[string]{"a", nil, nil, nil}
 
 	tests := []struct {
 		name string
-		node *Node
+		node *Node[string]
 		want string
 	}{
 		{"nil node", n1, "nil"},
@@ -35,16 +35,16 @@ func TestNodeString(t *testing.T) {
 }
 
 func TestNodeParent(t *testing.T) {
-	var n1, n2, n3 *Node
+	var n1, n2, n3 *Node[string]
 
-	n2 = &Node{"a", nil, nil, nil}
-	n3 = &Node{"b", n2, nil, nil}
+	n2 = &Node[string]{"a", nil, nil, nil}
+	n3 = &Node[string]{"b", n2, nil, nil}
 
 	tests := []struct {
 		name      string
-		node      *Node
+		node      *Node[string]
 		expError  error
-		parent    *Node
+		parent    *Node[string]
 		parentStr string
 	}{
 		{"nil node", n1, nodeNilError, nil, "nil"},
@@ -75,16 +75,16 @@ func TestNodeParent(t *testing.T) {
 
 		// construct an expected parent queue
 		expParents := []string{"nil", "a", "a", "b", "b", "c", "c"}
-		qParents := sgquezlib.SemiGenericQueue[*Node]{}
+		qParents := sgquezlib.SemiGenericQueue[*Node[string]]{}
 		for _, p := range expParents {
-			err2 := qParents.Enqueue(&Node{data: p})
+			err2 := qParents.Enqueue(&Node[string]{data: p})
 			if err2 != nil {
 				t.Fatalf("Enqueue() failed with error: %v", err2)
 			}
 		}
 
 		// Do a breadth first search and check if the parent of each node is what we expected
-		queue := sgquezlib.SemiGenericQueue[*Node]{}
+		queue := sgquezlib.SemiGenericQueue[*Node[string]]{}
 		err = queue.Enqueue(bt.root)
 		if err != nil {
 			t.Fatalf("Enqueue() failed with error: %v", err)
@@ -130,17 +130,17 @@ func TestNodeParent(t *testing.T) {
 }
 
 func TestNodeLeftChild(t *testing.T) {
-	var n1, n2, n3 *Node
+	var n1, n2, n3 *Node[string]
 
-	n2 = &Node{"a", nil, nil, nil}
-	n3 = &Node{"b", n2, nil, nil}
+	n2 = &Node[string]{"a", nil, nil, nil}
+	n3 = &Node[string]{"b", n2, nil, nil}
 	n2.left = n3
 
 	tests := []struct {
 		name      string
-		node      *Node
+		node      *Node[string]
 		expError  error
-		leftChild *Node
+		leftChild *Node[string]
 		lChildStr string
 	}{
 		{"nil node", n1, nodeNilError, nil, "nil"},
@@ -171,16 +171,16 @@ func TestNodeLeftChild(t *testing.T) {
 
 		// construct an expected left child queue
 		expLChildren := []string{"b", "d", "f", "nil", "nil", "nil", "nil"}
-		qLChildren := sgquezlib.SemiGenericQueue[*Node]{}
+		qLChildren := sgquezlib.SemiGenericQueue[*Node[string]]{}
 		for _, lc := range expLChildren {
-			err2 := qLChildren.Enqueue(&Node{data: lc})
+			err2 := qLChildren.Enqueue(&Node[string]{data: lc})
 			if err2 != nil {
 				t.Fatalf("Enqueue() failed with error: %v", err2)
 			}
 		}
 
 		// Do a breadth first search and check if the left child of each node is what we expected
-		queue := sgquezlib.SemiGenericQueue[*Node]{}
+		queue := sgquezlib.SemiGenericQueue[*Node[string]]{}
 		err = queue.Enqueue(bt.root)
 		if err != nil {
 			t.Fatalf("Enqueue() failed with error: %v", err)
@@ -226,17 +226,17 @@ func TestNodeLeftChild(t *testing.T) {
 }
 
 func TestNodeRightChild(t *testing.T) {
-	var n1, n2, n3 *Node
+	var n1, n2, n3 *Node[string]
 
-	n2 = &Node{"a", nil, nil, nil}
-	n3 = &Node{"b", n2, nil, nil}
+	n2 = &Node[string]{"a", nil, nil, nil}
+	n3 = &Node[string]{"b", n2, nil, nil}
 	n2.right = n3
 
 	tests := []struct {
 		name       string
-		node       *Node
+		node       *Node[string]
 		expError   error
-		rightChild *Node
+		rightChild *Node[string]
 		rChildStr  string
 	}{
 		{"nil node", n1, nodeNilError, nil, "nil"},
@@ -267,16 +267,16 @@ func TestNodeRightChild(t *testing.T) {
 
 		// construct an expected right child queue
 		expRChildren := []string{"c", "e", "g", "nil", "nil", "nil", "nil"}
-		qRChildren := sgquezlib.SemiGenericQueue[*Node]{}
+		qRChildren := sgquezlib.SemiGenericQueue[*Node[string]]{}
 		for _, lc := range expRChildren {
-			err2 := qRChildren.Enqueue(&Node{data: lc})
+			err2 := qRChildren.Enqueue(&Node[string]{data: lc})
 			if err2 != nil {
 				t.Fatalf("Enqueue() failed with error: %v", err2)
 			}
 		}
 
 		// Do a breadth first search and check if the right child of each node is what we expected
-		queue := sgquezlib.SemiGenericQueue[*Node]{}
+		queue := sgquezlib.SemiGenericQueue[*Node[string]]{}
 		err = queue.Enqueue(bt.root)
 		if err != nil {
 			t.Fatalf("Enqueue() failed with error: %v", err)
@@ -322,12 +322,12 @@ func TestNodeRightChild(t *testing.T) {
 }
 
 func TestIsNil(t *testing.T) {
-	var bt1 *BinaryTree
-	bt2 := &BinaryTree{}
+	var bt1 *BinaryTree[string]
+	bt2 := &BinaryTree[string]{}
 
 	tests := []struct {
 		name string
-		bt   *BinaryTree
+		bt   *BinaryTree[string]
 		want bool
 	}{
 		{"nil true", bt1, true},
@@ -346,15 +346,15 @@ func TestIsNil(t *testing.T) {
 }
 
 func TestIsEmpty(t *testing.T) {
-	var bt1 *BinaryTree
-	bt2 := &BinaryTree{}
+	var bt1 *BinaryTree[string]
+	bt2 := &BinaryTree[string]{}
 
-	root := &Node{}
-	bt3 := &BinaryTree{root, root}
+	root := &Node[string]{}
+	bt3 := &BinaryTree[string]{root: root, lastLeaf: root, count: 1}
 
 	tests := []struct {
 		name string
-		bt   *BinaryTree
+		bt   *BinaryTree[string]
 		want bool
 	}{
 		{"nil binary tree", bt1, true},
@@ -375,29 +375,29 @@ func TestIsEmpty(t *testing.T) {
 
 func TestRoot(t *testing.T) {
 
-	var bt1, bt2, bt3, bt4, bt5 *BinaryTree
-	bt2 = &BinaryTree{}
+	var bt1, bt2, bt3, bt4, bt5 *BinaryTree[string]
+	bt2 = &BinaryTree[string]{}
 
-	r1 := &Node{"1", nil, nil, nil}
-	bt3 = &BinaryTree{r1, r1}
+	r1 := &Node[string]{"1", nil, nil, nil}
+	bt3 = &BinaryTree[string]{root: r1, lastLeaf: r1, count: 1}
 
-	n2 := &Node{"b", nil, nil, nil}
-	r2 := &Node{"a", nil, n2, nil}
+	n2 := &Node[string]{"b", nil, nil, nil}
+	r2 := &Node[string]{"a", nil, n2, nil}
 	n2.parent = r2
 
-	bt4 = &BinaryTree{r2, n2}
+	bt4 = &BinaryTree[string]{root: r2, lastLeaf: n2, count: 2}
 
-	n3 := &Node{"l", nil, nil, nil}
-	n4 := &Node{"r", nil, nil, nil}
-	r3 := &Node{"m", nil, n3, n4}
+	n3 := &Node[string]{"l", nil, nil, nil}
+	n4 := &Node[string]{"r", nil, nil, nil}
+	r3 := &Node[string]{"m", nil, n3, n4}
 	n3.parent = r3
 	n4.parent = r3
 
-	bt5 = &BinaryTree{r3, n4}
+	bt5 = &BinaryTree[string]{root: r3, lastLeaf: n4, count: 3}
 
 	tests := []struct {
 		name string
-		bt   *BinaryTree
+		bt   *BinaryTree[string]
 		want string
 	}{
 		{"nil binary tree", bt1, "nil"},
@@ -419,14 +419,14 @@ func TestRoot(t *testing.T) {
 }
 
 func TestLastLeaf(t *testing.T) {
-	var bt *BinaryTree
+	var bt *BinaryTree[string]
 
 	node := bt.LastLeaf()
 	if node != nil {
 		t.Fatalf("Last leaf of a nil tree should be nil")
 	}
 
-	bt = &BinaryTree{}
+	bt = &BinaryTree[string]{}
 	node = bt.LastLeaf()
 	if node != nil {
 		t.Fatalf("Last leaf of an empty tree should be nil")
@@ -458,7 +458,7 @@ func TestLastLeaf(t *testing.T) {
 }
 
 func TestAddNodeBFS(t *testing.T) {
-	var bt *BinaryTree
+	var bt *BinaryTree[string]
 	err := bt.AddNodeBFS("a")
 	if err == nil {
 		t.Error("AddNode() on a nil Binary Tree should have returned an error")
@@ -466,7 +466,7 @@ func TestAddNodeBFS(t *testing.T) {
 		fmt.Println(err)
 	}
 
-	bt = &BinaryTree{}
+	bt = &BinaryTree[string]{}
 	vals := []string{"a", "b", "c", "d", "e"}
 	for _, str := range vals {
 		err := bt.AddNodeBFS(str)
@@ -475,7 +475,7 @@ func TestAddNodeBFS(t *testing.T) {
 		}
 	}
 
-	queue := &sgquezlib.SemiGenericQueue[*Node]{}
+	queue := &sgquezlib.SemiGenericQueue[*Node[string]]{}
 	err2 := queue.Enqueue(bt.root)
 	if err2 != nil {
 		t.Fatalf("Enqueue() failed with error: %v", err2)
@@ -522,7 +522,7 @@ func TestConstructFromValues(t *testing.T) {
 		t.Fatalf("ConstructFromValues() failed with error: %v", err)
 	}
 
-	queue := &sgquezlib.SemiGenericQueue[*Node]{}
+	queue := &sgquezlib.SemiGenericQueue[*Node[string]]{}
 	err2 := queue.Enqueue(bt.root)
 	if err2 != nil {
 		t.Fatalf("Enqueue() failed with error: %v", err2)
@@ -562,7 +562,7 @@ func TestConstructFromValues(t *testing.T) {
 }
 
 func TestTraverseBFS(t *testing.T) {
-	var bt *BinaryTree
+	var bt *BinaryTree[string]
 
 	_, err := bt.TraverseBFS()
 	if err == nil {
@@ -571,7 +571,7 @@ func TestTraverseBFS(t *testing.T) {
 		fmt.Println(err)
 	}
 
-	bt = &BinaryTree{}
+	bt = &BinaryTree[string]{}
 	_, err = bt.TraverseBFS()
 	if err == nil {
 		t.Error("TraverseBFS() on an empty tree should return an error")
@@ -616,7 +616,7 @@ func TestTraverseBFS(t *testing.T) {
 }
 
 func TestTraverseDFSPreOrderRecursive(t *testing.T) {
-	var bt *BinaryTree
+	var bt *BinaryTree[string]
 
 	_, err := bt.TraverseDFSPreOrderRecursive()
 	if err == nil {
@@ -625,7 +625,7 @@ func TestTraverseDFSPreOrderRecursive(t *testing.T) {
 		fmt.Println(err)
 	}
 
-	bt = &BinaryTree{}
+	bt = &BinaryTree[string]{}
 	_, err = bt.TraverseDFSPreOrderRecursive()
 	if err == nil {
 		t.Error("TraverseDFSPreOrderRecursive() on an empty tree should return an error")
@@ -670,7 +670,7 @@ func TestTraverseDFSPreOrderRecursive(t *testing.T) {
 }
 
 func TestTraverseDFSPreOrderIterative(t *testing.T) {
-	var bt *BinaryTree
+	var bt *BinaryTree[string]
 
 	_, err := bt.TraverseDFSPreOrderIterative()
 	if err == nil {
@@ -679,7 +679,7 @@ func TestTraverseDFSPreOrderIterative(t *testing.T) {
 		fmt.Println(err)
 	}
 
-	bt = &BinaryTree{}
+	bt = &BinaryTree[string]{}
 	_, err = bt.TraverseDFSPreOrderIterative()
 	if err == nil {
 		t.Error("TraverseDFSPreOrderIterative() on an empty tree should return an error")
@@ -724,7 +724,7 @@ func TestTraverseDFSPreOrderIterative(t *testing.T) {
 }
 
 func TestTraverseDFSInOrderRecursive(t *testing.T) {
-	var bt *BinaryTree
+	var bt *BinaryTree[string]
 
 	_, err := bt.TraverseDFSInOrderRecursive()
 	if err == nil {
@@ -733,7 +733,7 @@ func TestTraverseDFSInOrderRecursive(t *testing.T) {
 		fmt.Println(err)
 	}
 
-	bt = &BinaryTree{}
+	bt = &BinaryTree[string]{}
 	_, err = bt.TraverseDFSInOrderRecursive()
 	if err == nil {
 		t.Error("TraverseDFSPreOrderRecursive() on an empty tree should return an error")
@@ -778,7 +778,7 @@ func TestTraverseDFSInOrderRecursive(t *testing.T) {
 }
 
 func TestTraverseDFSInOrderIterative(t *testing.T) {
-	var bt *BinaryTree
+	var bt *BinaryTree[string]
 
 	_, err := bt.TraverseDFSInOrderIterative()
 	if err == nil {
@@ -787,8 +787,8 @@ func TestTraverseDFSInOrderIterative(t *testing.T) {
 		fmt.Println(err)
 	}
 
-	bt = &BinaryTree{}
-	_, err = bt.TraverseDFSPreOrderIterative()
+	bt = &BinaryTree[string]{}
+	_, err = bt.TraverseDFSInOrderIterative()
 	if err == nil {
 		t.Error("TraverseDFSInOrderIterative() on an empty tree should return an error")
 	} else {
@@ -802,12 +802,12 @@ func TestTraverseDFSInOrderIterative(t *testing.T) {
 		want   string
 	}{
 		{"1 element tree", "a", "-a-"},
-		{"2 element tree", "b", "-a--b-"},
-		{"3 element tree", "c", "-a--b--c-"},
-		{"4 element tree", "d", "-a--b--d--c-"},
-		{"5 element tree", "e", "-a--b--d--e--c-"},
-		{"6 element tree", "f", "-a--b--d--e--c--f-"},
-		{"7 element tree", "g", "-a--b--d--e--c--f--g-"},
+		{"2 element tree", "b", "-b--a-"},
+		{"3 element tree", "c", "-b--a--c-"},
+		{"4 element tree", "d", "-d--b--a--c-"},
+		{"5 element tree", "e", "-d--b--e--a--c-"},
+		{"6 element tree", "f", "-d--b--e--a--f--c-"},
+		{"7 element tree", "g", "-d--b--e--a--f--c--g-"},
 	}
 
 	for _, test := range tests {
@@ -817,7 +817,7 @@ func TestTraverseDFSInOrderIterative(t *testing.T) {
 			if err != nil {
 				t.Errorf("ConstructFromValues() failed with error: %v", err)
 			} else {
-				got, err2 := bt.TraverseDFSPreOrderIterative()
+				got, err2 := bt.TraverseDFSInOrderIterative()
 				if err2 != nil {
 					t.Errorf("TraverseDFSInOrderIterative() failed with error: %v", err2)
 				} else {
@@ -832,7 +832,7 @@ func TestTraverseDFSInOrderIterative(t *testing.T) {
 }
 
 func TestTraverseDFSPostOrderRecursive(t *testing.T) {
-	var bt *BinaryTree
+	var bt *BinaryTree[string]
 
 	_, err := bt.TraverseDFSPostOrderRecursive()
 	if err == nil {
@@ -841,7 +841,7 @@ func TestTraverseDFSPostOrderRecursive(t *testing.T) {
 		fmt.Println(err)
 	}
 
-	bt = &BinaryTree{}
+	bt = &BinaryTree[string]{}
 	_, err = bt.TraverseDFSPostOrderRecursive()
 	if err == nil {
 		t.Error("TraverseDFSPostOrderRecursive() on an empty tree should return an error")
@@ -886,7 +886,7 @@ func TestTraverseDFSPostOrderRecursive(t *testing.T) {
 }
 
 func TestTraverseDFSPostOrderIterative(t *testing.T) {
-	var bt *BinaryTree
+	var bt *BinaryTree[string]
 
 	_, err := bt.TraverseDFSPostOrderIterative()
 	if err == nil {
@@ -895,7 +895,7 @@ func TestTraverseDFSPostOrderIterative(t *testing.T) {
 		fmt.Println(err)
 	}
 
-	bt = &BinaryTree{}
+	bt = &BinaryTree[string]{}
 	_, err = bt.TraverseDFSPostOrderIterative()
 	if err == nil {
 		t.Error("TraverseDFSPostOrderIterative() on an empty tree should return an error")
@@ -940,7 +940,7 @@ func TestTraverseDFSPostOrderIterative(t *testing.T) {
 }
 
 func TestContains(t *testing.T) {
-	var bt *BinaryTree
+	var bt *BinaryTree[string]
 	_, err := bt.Contains("a")
 	if err == nil {
 		t.Error("Contains() on a nil tree should return an error")
@@ -948,7 +948,7 @@ func TestContains(t *testing.T) {
 		fmt.Println(err)
 	}
 
-	bt = &BinaryTree{}
+	bt = &BinaryTree[string]{}
 	_, err = bt.Contains("a")
 	if err == nil {
 		t.Error("Contains() on an empty tree should return an error")
@@ -979,7 +979,7 @@ func TestContains(t *testing.T) {
 		}
 	}
 
-	vowelTree := &BinaryTree{}
+	vowelTree := &BinaryTree[string]{}
 	vowels := []string{"a", "e", "i", "o", "u"}
 
 	for _, vowel := range vowels {
@@ -1037,7 +1037,7 @@ func TestContains(t *testing.T) {
 }
 
 func TestRemoveValue(t *testing.T) {
-	var bt *BinaryTree
+	var bt *BinaryTree[string]
 	err := bt.RemoveValue("a")
 	if err == nil {
 		t.Error("RemoveValue() on a nil tree should return an error")
@@ -1045,7 +1045,7 @@ func TestRemoveValue(t *testing.T) {
 		fmt.Println(err)
 	}
 
-	bt = &BinaryTree{}
+	bt = &BinaryTree[string]{}
 	err = bt.RemoveValue("a")
 	if err == nil {
 		t.Error("RemoveValue() on an empty tree should return an error")
@@ -1132,7 +1132,7 @@ func TestRemoveValue(t *testing.T) {
 
 	t.Run("remove values from a binary tree till empty 2", func(t *testing.T) {
 
-		consonantTree := &BinaryTree{}
+		consonantTree := &BinaryTree[string]{}
 		consonants := []string{"b", "c", "d", "f", "g", "h", "j", "k", "l", "m", "n", "p", "q", "r", "s", "t", "v", "w", "x", "y", "z"}
 
 		for _, consonant := range consonants {
@@ -1205,3 +1205,206 @@ func TestRemoveValue(t *testing.T) {
 		}
 	})
 }
+
+func TestNonStringElements(t *testing.T) {
+
+	t.Run("int elements", func(t *testing.T) {
+		bt, err := ConstructFromValues(1, 2, 3, 4, 5)
+		if err != nil {
+			t.Fatalf("ConstructFromValues() failed with error: %v", err)
+		}
+
+		want := "-4--2--5--1--3-"
+		got, err := bt.TraverseDFSInOrderRecursive()
+		if err != nil {
+			t.Fatalf("TraverseDFSInOrderRecursive() failed with error: %v", err)
+		} else if got != want {
+			t.Errorf("TraverseDFSInOrderRecursive() returned incorrect results, want: %v, got: %v", want, got)
+		}
+
+		err = bt.RemoveValue(2)
+		if err != nil {
+			t.Fatalf("RemoveValue() failed with error: %v", err)
+		}
+
+		want = "-1--5--3--4-"
+		got, err = bt.TraverseBFS()
+		if err != nil {
+			t.Fatalf("TraverseBFS() failed with error: %v", err)
+		} else if got != want {
+			t.Errorf("RemoveValue() gave incorrect results, want: %v, got: %v", want, got)
+		}
+	})
+
+	t.Run("struct elements", func(t *testing.T) {
+		type point struct {
+			x, y int
+		}
+
+		bt, err := ConstructFromValues(point{0, 0}, point{1, 2}, point{3, 4})
+		if err != nil {
+			t.Fatalf("ConstructFromValues() failed with error: %v", err)
+		}
+
+		tests := []struct {
+			name string
+			val  point
+			want bool
+		}{
+			{"present value", point{1, 2}, true},
+			{"absent value", point{2, 1}, false},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got, err2 := bt.Contains(test.val)
+				if err2 != nil {
+					t.Fatalf("Contains() failed with error: %v", err2)
+				} else if got != test.want {
+					t.Errorf("Contains() returned incorrect results, want: %v, got: %v", test.want, got)
+				}
+			})
+		}
+	})
+
+	t.Run("pointer elements", func(t *testing.T) {
+		a, b, c := new(int), new(int), new(int)
+
+		bt := &BinaryTree[*int]{}
+		for _, ptr := range []*int{a, b} {
+			err := bt.AddNodeBFS(ptr)
+			if err != nil {
+				t.Fatalf("AddNodeBFS() failed with error: %v", err)
+			}
+		}
+
+		// pointers are compared by identity, not by the value they point to
+		got, err := bt.Contains(c)
+		if err != nil {
+			t.Fatalf("Contains() failed with error: %v", err)
+		} else if got {
+			t.Errorf("Contains() returned incorrect results, want: %v, got: %v", false, got)
+		}
+
+		err = bt.RemoveValue(b)
+		if err != nil {
+			t.Fatalf("RemoveValue() failed with error: %v", err)
+		} else if bt.LastLeaf().data != a {
+			t.Errorf("RemoveValue() left an incorrect last leaf")
+		}
+	})
+
+	t.Run("string alias", func(t *testing.T) {
+		var bt *StringBinaryTree
+		bt, err := ConstructFromValues("a", "b")
+		if err != nil {
+			t.Fatalf("ConstructFromValues() failed with error: %v", err)
+		}
+
+		var root *StringNode = bt.Root()
+		if root.String() != "a" {
+			t.Errorf("Root() returned incorrect results, want: %v, got: %v", "a", root)
+		}
+	})
+}
+
+func TestCount(t *testing.T) {
+	var bt *BinaryTree[string]
+	_, err := bt.Count()
+	if !errors.Is(err, treeNilError) {
+		t.Fatalf("Count() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	bt = &BinaryTree[string]{}
+
+	tests := []struct {
+		name   string
+		add    string
+		remove string
+		want   int
+	}{
+		{"empty tree", "", "", 0},
+		{"add a", "a", "", 1},
+		{"add b", "b", "", 2},
+		{"add c", "c", "", 3},
+		{"remove a", "", "a", 2},
+		{"add d", "d", "", 3},
+		{"remove d", "", "d", 2},
+		{"remove c", "", "c", 1},
+		{"remove b", "", "b", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.add != "" {
+				err2 := bt.AddNodeBFS(test.add)
+				if err2 != nil {
+					t.Fatalf("AddNodeBFS() failed with error: %v", err2)
+				}
+			}
+
+			if test.remove != "" {
+				err2 := bt.RemoveValue(test.remove)
+				if err2 != nil {
+					t.Fatalf("RemoveValue() failed with error: %v", err2)
+				}
+			}
+
+			got, err2 := bt.Count()
+			if err2 != nil {
+				t.Fatalf("Count() failed with error: %v", err2)
+			} else if got != test.want {
+				t.Errorf("Count() returned incorrect results, want: %v, got: %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestLargeCompleteTree(t *testing.T) {
+	const size = 1 << 20
+
+	bt := &BinaryTree[int]{}
+	for i := 1; i <= size; i++ {
+		err := bt.AddNodeBFS(i)
+		if err != nil {
+			t.Fatalf("AddNodeBFS() failed with error: %v", err)
+		}
+	}
+
+	// the node at every position holds the value that was added at that position
+	for _, position := range []int{1, 2, 3, 1000, size / 2, size - 1, size} {
+		node := bt.nodeAt(position)
+		if node == nil || node.data != position {
+			t.Errorf("incorrect node at position %v, got: %v", position, node)
+		}
+	}
+
+	if bt.LastLeaf().data != size {
+		t.Errorf("LastLeaf() returned incorrect results, want: %v, got: %v", size, bt.LastLeaf())
+	}
+
+	// removing the root moves the last leaf's value into it, and the previous node becomes the last leaf
+	for i := 0; i < 3; i++ {
+		err := bt.RemoveValue(bt.Root().data)
+		if err != nil {
+			t.Fatalf("RemoveValue() failed with error: %v", err)
+		}
+	}
+
+	cnt, err := bt.Count()
+	if err != nil {
+		t.Fatalf("Count() failed with error: %v", err)
+	} else if cnt != size-3 {
+		t.Errorf("Count() returned incorrect results, want: %v, got: %v", size-3, cnt)
+	}
+
+	if bt.Root().data != size-2 || bt.LastLeaf().data != size-3 {
+		t.Errorf("RemoveValue() gave incorrect results, root: %v, last leaf: %v", bt.Root(), bt.LastLeaf())
+	}
+
+	if bt.LastLeaf() != bt.nodeAt(cnt) || bt.LastLeaf().parent != bt.nodeAt(cnt/2) {
+		t.Errorf("the last leaf is not at the last position of the tree")
+	}
+}
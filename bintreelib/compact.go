@@ -0,0 +1,298 @@
+package bintreelib
+
+import (
+	"fmt"
+	"iter"
+)
+
+// CompactBinaryTree is an array backed complete binary tree of comparable values
+// The values are stored in breadth first order, so the children of the value at index i are at 2i+1 and 2i+2,
+// and its parent is at (i-1)/2. There are no per node allocations, which keeps the garbage collector out of large trees
+type CompactBinaryTree[T comparable] struct {
+	values []T
+}
+
+// IsNil tells you if this pointer to the Compact Binary Tree is nil
+func (ct *CompactBinaryTree[T]) IsNil() bool {
+	return ct == nil
+}
+
+// IsEmpty checks whether a Compact Binary Tree is empty
+func (ct *CompactBinaryTree[T]) IsEmpty() bool {
+	return ct.IsNil() || len(ct.values) == 0
+}
+
+// Count returns the number of values in the Compact Binary Tree
+func (ct *CompactBinaryTree[T]) Count() (int, error) {
+	if ct.IsNil() {
+		return invalidCount, treeNilError
+	}
+	return len(ct.values), nil
+}
+
+// Root returns the value at the root of the Compact Binary Tree
+func (ct *CompactBinaryTree[T]) Root() (T, error) {
+	var zero T
+	if ct.IsNil() {
+		return zero, treeNilError
+	}
+
+	if ct.IsEmpty() {
+		return zero, treeEmptyError
+	}
+
+	return ct.values[0], nil
+}
+
+// LastLeaf returns the value at the last position (in breadth first order) of the Compact Binary Tree
+func (ct *CompactBinaryTree[T]) LastLeaf() (T, error) {
+	var zero T
+	if ct.IsNil() {
+		return zero, treeNilError
+	}
+
+	if ct.IsEmpty() {
+		return zero, treeEmptyError
+	}
+
+	return ct.values[len(ct.values)-1], nil
+}
+
+// AddNodeBFS adds a value at the next free position in breadth first order (keeping the tree complete)
+func (ct *CompactBinaryTree[T]) AddNodeBFS(val T) error {
+	if ct.IsNil() {
+		return treeNilError
+	}
+
+	ct.values = append(ct.values, val)
+	return nil
+}
+
+// ConstructCompactFromValues creates a new compact binary tree, and adds the given values to it in breadth first order
+func ConstructCompactFromValues[T comparable](values ...T) *CompactBinaryTree[T] {
+	ct := &CompactBinaryTree[T]{values: make([]T, 0, len(values))}
+	ct.values = append(ct.values, values...)
+	return ct
+}
+
+// Contains checks whether a given value is present in the Compact Binary Tree
+func (ct *CompactBinaryTree[T]) Contains(val T) (bool, error) {
+	if ct.IsNil() {
+		return false, treeNilError
+	}
+
+	if ct.IsEmpty() {
+		return false, treeEmptyError
+	}
+
+	return ct.indexOf(val) != invalidCount, nil
+}
+
+// indexOf returns the index of the first occurrence (in breadth first order) of the given value, or invalidCount if absent
+func (ct *CompactBinaryTree[T]) indexOf(val T) int {
+	for i, v := range ct.values {
+		if v == val {
+			return i
+		}
+	}
+	return invalidCount
+}
+
+// RemoveValue removes the first occurrence (in breadth first order) of the given value from the Compact Binary Tree
+// Just like in the pointer based tree, the value at the last position takes the place of the removed one
+func (ct *CompactBinaryTree[T]) RemoveValue(val T) error {
+	if ct.IsNil() {
+		return treeNilError
+	}
+
+	if ct.IsEmpty() {
+		return treeEmptyError
+	}
+
+	index := ct.indexOf(val)
+	if index == invalidCount {
+		return fmt.Errorf("the value %v was not found in the binary tree", val)
+	}
+
+	last := len(ct.values) - 1
+	ct.values[index] = ct.values[last]
+
+	// clear the vacated slot so it does not keep its value reachable
+	var zero T
+	ct.values[last] = zero
+	ct.values = ct.values[:last]
+	return nil
+}
+
+// All returns an iterator over the values of the compact binary tree, in the order they were added (breadth first order)
+func (ct *CompactBinaryTree[T]) All() iter.Seq[T] {
+	return ct.BFS()
+}
+
+// BFS returns an iterator over the values of the compact binary tree using Breadth First Search
+// Since the values are stored in breadth first order, this is a walk over the backing slice
+func (ct *CompactBinaryTree[T]) BFS() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if ct.IsEmpty() {
+			return
+		}
+
+		for _, v := range ct.values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// PreOrder returns an iterator over the values of the compact binary tree using Depth First Search
+// In a pre-order manner (visit a node, then its left subtree, followed by its right subtree)
+func (ct *CompactBinaryTree[T]) PreOrder() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if ct.IsEmpty() {
+			return
+		}
+		compactPreOrder(ct.values, 0, yield)
+	}
+}
+
+// InOrder returns an iterator over the values of the compact binary tree using Depth First Search
+// In an in-order manner (visit the left subtree, then the node, followed by the right subtree)
+func (ct *CompactBinaryTree[T]) InOrder() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if ct.IsEmpty() {
+			return
+		}
+		compactInOrder(ct.values, 0, yield)
+	}
+}
+
+// PostOrder returns an iterator over the values of the compact binary tree using Depth First Search
+// In a post-order manner (visit the left subtree, then the right subtree, followed by the node)
+func (ct *CompactBinaryTree[T]) PostOrder() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if ct.IsEmpty() {
+			return
+		}
+		compactPostOrder(ct.values, 0, yield)
+	}
+}
+
+// the recursion depth of these helpers is the height of the tree, which is O(log n) for a complete tree
+// each of them returns false once the caller has asked to stop
+
+func compactPreOrder[T comparable](values []T, index int, yield func(T) bool) bool {
+	if index >= len(values) {
+		return true
+	}
+	return yield(values[index]) &&
+		compactPreOrder(values, 2*index+1, yield) &&
+		compactPreOrder(values, 2*index+2, yield)
+}
+
+func compactInOrder[T comparable](values []T, index int, yield func(T) bool) bool {
+	if index >= len(values) {
+		return true
+	}
+	return compactInOrder(values, 2*index+1, yield) &&
+		yield(values[index]) &&
+		compactInOrder(values, 2*index+2, yield)
+}
+
+func compactPostOrder[T comparable](values []T, index int, yield func(T) bool) bool {
+	if index >= len(values) {
+		return true
+	}
+	return compactPostOrder(values, 2*index+1, yield) &&
+		compactPostOrder(values, 2*index+2, yield) &&
+		yield(values[index])
+}
+
+// traverse builds the traversal string out of one of the iterators, in the same format as the pointer based tree
+func (ct *CompactBinaryTree[T]) traverse(seq iter.Seq[T]) (string, error) {
+	if ct.IsNil() {
+		return "", treeNilError
+	}
+
+	if ct.IsEmpty() {
+		return "", treeEmptyError
+	}
+
+	treeStr := ""
+	for v := range seq {
+		treeStr += fmt.Sprintf("-%v-", v)
+	}
+	return treeStr, nil
+}
+
+// TraverseBFS returns a string of the values of the compact binary tree in breadth first order
+func (ct *CompactBinaryTree[T]) TraverseBFS() (string, error) {
+	return ct.traverse(ct.BFS())
+}
+
+// TraverseDFSPreOrder returns a string of the values of the compact binary tree in depth first pre-order
+func (ct *CompactBinaryTree[T]) TraverseDFSPreOrder() (string, error) {
+	return ct.traverse(ct.PreOrder())
+}
+
+// TraverseDFSInOrder returns a string of the values of the compact binary tree in depth first in-order
+func (ct *CompactBinaryTree[T]) TraverseDFSInOrder() (string, error) {
+	return ct.traverse(ct.InOrder())
+}
+
+// TraverseDFSPostOrder returns a string of the values of the compact binary tree in depth first post-order
+func (ct *CompactBinaryTree[T]) TraverseDFSPostOrder() (string, error) {
+	return ct.traverse(ct.PostOrder())
+}
+
+// ToCompact copies the binary tree into a compact (array backed) binary tree
+// A complete binary tree's breadth first order is exactly the array layout, so trees that are not complete can't be copied
+func (bt *BinaryTree[T]) ToCompact() (*CompactBinaryTree[T], error) {
+	if bt.IsNil() {
+		return nil, treeNilError
+	}
+
+	if bt.incomplete {
+		return nil, treeIncompleteError
+	}
+
+	ct := &CompactBinaryTree[T]{values: make([]T, 0, bt.count)}
+	for v := range bt.BFS() {
+		ct.values = append(ct.values, v)
+	}
+	return ct, nil
+}
+
+// FromCompact builds a pointer based binary tree out of a compact binary tree, linking every node to its parent directly
+func FromCompact[T comparable](ct *CompactBinaryTree[T]) (*BinaryTree[T], error) {
+	if ct.IsNil() {
+		return nil, treeNilError
+	}
+
+	bt := &BinaryTree[T]{}
+	if ct.IsEmpty() {
+		return bt, nil
+	}
+
+	// every node gets its own allocation, so a node removed or detached later does not keep the others alive
+	nodes := make([]*Node[T], len(ct.values))
+	for i, v := range ct.values {
+		nodes[i] = &Node[T]{data: v}
+		if i == 0 {
+			continue
+		}
+
+		parent := nodes[(i-1)/2]
+		nodes[i].parent = parent
+		if i%2 == 1 {
+			parent.left = nodes[i]
+		} else {
+			parent.right = nodes[i]
+		}
+	}
+
+	bt.root = nodes[0]
+	bt.lastLeaf = nodes[len(nodes)-1]
+	bt.count = len(nodes)
+	return bt, nil
+}
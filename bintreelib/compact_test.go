@@ -0,0 +1,300 @@
+package bintreelib
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestCompactTraversals(t *testing.T) {
+	var nilTree *CompactBinaryTree[string]
+	emptyTree := &CompactBinaryTree[string]{}
+	ct := ConstructCompactFromValues("a", "b", "c", "d", "e", "f")
+
+	tests := []struct {
+		name     string
+		traverse func(ct *CompactBinaryTree[string]) (string, error)
+		seq      func(ct *CompactBinaryTree[string]) iter.Seq[string]
+		btSeq    func(bt *BinaryTree[string]) iter.Seq[string]
+		want     string
+	}{
+		{"BFS", (*CompactBinaryTree[string]).TraverseBFS, (*CompactBinaryTree[string]).BFS, (*BinaryTree[string]).BFS, "-a--b--c--d--e--f-"},
+		{"PreOrder", (*CompactBinaryTree[string]).TraverseDFSPreOrder, (*CompactBinaryTree[string]).PreOrder, (*BinaryTree[string]).PreOrder, "-a--b--d--e--c--f-"},
+		{"InOrder", (*CompactBinaryTree[string]).TraverseDFSInOrder, (*CompactBinaryTree[string]).InOrder, (*BinaryTree[string]).InOrder, "-d--b--e--a--f--c-"},
+		{"PostOrder", (*CompactBinaryTree[string]).TraverseDFSPostOrder, (*CompactBinaryTree[string]).PostOrder, (*BinaryTree[string]).PostOrder, "-d--e--b--f--c--a-"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.traverse(nilTree)
+			if !errors.Is(err, treeNilError) {
+				t.Errorf("traversal on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+			} else {
+				fmt.Println(err)
+			}
+
+			_, err = test.traverse(emptyTree)
+			if !errors.Is(err, treeEmptyError) {
+				t.Errorf("traversal on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+			} else {
+				fmt.Println(err)
+			}
+
+			got, err := test.traverse(ct)
+			if err != nil {
+				t.Fatalf("traversal failed with error: %v", err)
+			} else if got != test.want {
+				t.Errorf("traversal returned incorrect results, want: %v, got: %v", test.want, got)
+			}
+
+			// the compact tree should traverse exactly like the pointer based tree with the same values
+			bt, err := ConstructFromValues("a", "b", "c", "d", "e", "f")
+			if err != nil {
+				t.Fatalf("ConstructFromValues() failed with error: %v", err)
+			}
+
+			want := slices.Collect(test.btSeq(bt))
+			if gotValues := slices.Collect(test.seq(ct)); !slices.Equal(gotValues, want) {
+				t.Errorf("%v() returned incorrect results, want: %v, got: %v", test.name, want, gotValues)
+			}
+		})
+	}
+}
+
+func TestCompactIteratorEarlyExit(t *testing.T) {
+	ct := ConstructCompactFromValues(1, 2, 3, 4, 5, 6, 7)
+
+	got := []int{}
+	for v := range ct.InOrder() {
+		got = append(got, v)
+		if len(got) == 3 {
+			break
+		}
+	}
+
+	want := []int{4, 2, 5}
+	if !slices.Equal(got, want) {
+		t.Errorf("InOrder() did not stop correctly, want: %v, got: %v", want, got)
+	}
+}
+
+func TestCompactContains(t *testing.T) {
+	var nilTree *CompactBinaryTree[int]
+	_, err := nilTree.Contains(1)
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("Contains() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	_, err = (&CompactBinaryTree[int]{}).Contains(1)
+	if !errors.Is(err, treeEmptyError) {
+		t.Errorf("Contains() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	ct := ConstructCompactFromValues(1, 2, 3, 4, 5)
+
+	tests := []struct {
+		val  int
+		want bool
+	}{
+		{1, true},
+		{5, true},
+		{3, true},
+		{0, false},
+		{6, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("contains %v", test.val), func(t *testing.T) {
+			got, err2 := ct.Contains(test.val)
+			if err2 != nil {
+				t.Fatalf("Contains() failed with error: %v", err2)
+			} else if got != test.want {
+				t.Errorf("Contains() returned incorrect results, want: %v, got: %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestCompactRemoveValue(t *testing.T) {
+	var nilTree *CompactBinaryTree[string]
+	err := nilTree.RemoveValue("a")
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("RemoveValue() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	err = (&CompactBinaryTree[string]{}).RemoveValue("a")
+	if !errors.Is(err, treeEmptyError) {
+		t.Errorf("RemoveValue() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	tests := []struct {
+		name    string
+		values  []string
+		remove  string
+		wantErr bool
+		want    []string
+	}{
+		{"single node", []string{"a"}, "a", false, []string{}},
+		{"absent value", []string{"a", "b"}, "c", true, []string{"a", "b"}},
+		{"remove root", []string{"a", "b", "c", "d"}, "a", false, []string{"d", "b", "c"}},
+		{"remove middle", []string{"a", "b", "c", "d", "e"}, "b", false, []string{"a", "e", "c", "d"}},
+		{"remove last leaf", []string{"a", "b", "c"}, "c", false, []string{"a", "b"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ct := ConstructCompactFromValues(test.values...)
+			bt, err2 := ConstructFromValues(test.values...)
+			if err2 != nil {
+				t.Fatalf("ConstructFromValues() failed with error: %v", err2)
+			}
+
+			err2 = ct.RemoveValue(test.remove)
+			if test.wantErr {
+				if err2 == nil {
+					t.Fatalf("RemoveValue() should have failed for the value %v", test.remove)
+				}
+				fmt.Println(err2)
+			} else if err2 != nil {
+				t.Fatalf("RemoveValue() failed with error: %v", err2)
+			}
+
+			got := slices.Collect(ct.All())
+			if !slices.Equal(got, test.want) {
+				t.Errorf("RemoveValue() gave incorrect results, want: %v, got: %v", test.want, got)
+			}
+
+			// removing from the pointer based tree should leave the same breadth first order
+			_ = bt.RemoveValue(test.remove)
+			if want := slices.Collect(bt.All()); !slices.Equal(got, want) {
+				t.Errorf("compact and pointer based trees differ after RemoveValue(), want: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestCompactRootAndLastLeaf(t *testing.T) {
+	ct := &CompactBinaryTree[int]{}
+
+	_, err := ct.Root()
+	if !errors.Is(err, treeEmptyError) {
+		t.Errorf("Root() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	for i := 1; i <= 4; i++ {
+		err = ct.AddNodeBFS(i)
+		if err != nil {
+			t.Fatalf("AddNodeBFS() failed with error: %v", err)
+		}
+	}
+
+	root, err := ct.Root()
+	if err != nil {
+		t.Fatalf("Root() failed with error: %v", err)
+	} else if root != 1 {
+		t.Errorf("Root() returned incorrect results, want: %v, got: %v", 1, root)
+	}
+
+	last, err := ct.LastLeaf()
+	if err != nil {
+		t.Fatalf("LastLeaf() failed with error: %v", err)
+	} else if last != 4 {
+		t.Errorf("LastLeaf() returned incorrect results, want: %v, got: %v", 4, last)
+	}
+
+	cnt, err := ct.Count()
+	if err != nil {
+		t.Fatalf("Count() failed with error: %v", err)
+	} else if cnt != 4 {
+		t.Errorf("Count() returned incorrect results, want: %v, got: %v", 4, cnt)
+	}
+}
+
+func TestCompactConversions(t *testing.T) {
+	var nilTree *BinaryTree[int]
+	_, err := nilTree.ToCompact()
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("ToCompact() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	_, err = FromCompact[int](nil)
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("FromCompact() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	for _, size := range []int{0, 1, 2, 3, 6, 7, 8, 100} {
+		t.Run(fmt.Sprintf("%v nodes", size), func(t *testing.T) {
+			values := make([]int, size)
+			for i := range values {
+				values[i] = i * 10
+			}
+
+			bt, err2 := ConstructFromValues(values...)
+			if err2 != nil {
+				t.Fatalf("ConstructFromValues() failed with error: %v", err2)
+			}
+
+			ct, err2 := bt.ToCompact()
+			if err2 != nil {
+				t.Fatalf("ToCompact() failed with error: %v", err2)
+			}
+			if got := slices.Collect(ct.All()); !slices.Equal(got, values) {
+				t.Errorf("ToCompact() gave incorrect results, want: %v, got: %v", values, got)
+			}
+
+			back, err2 := FromCompact(ct)
+			if err2 != nil {
+				t.Fatalf("FromCompact() failed with error: %v", err2)
+			}
+
+			for _, order := range []struct {
+				name      string
+				got, want iter.Seq[int]
+			}{
+				{"BFS", back.BFS(), bt.BFS()},
+				{"PreOrder", back.PreOrder(), bt.PreOrder()},
+				{"InOrder", back.InOrder(), bt.InOrder()},
+				{"PostOrder", back.PostOrder(), bt.PostOrder()},
+			} {
+				if got, want := slices.Collect(order.got), slices.Collect(order.want); !slices.Equal(got, want) {
+					t.Errorf("round trip changed the %v order, want: %v, got: %v", order.name, want, got)
+				}
+			}
+
+			cnt, err2 := back.Count()
+			if err2 != nil {
+				t.Fatalf("Count() failed with error: %v", err2)
+			} else if cnt != size {
+				t.Errorf("Count() returned incorrect results, want: %v, got: %v", size, cnt)
+			}
+
+			// the rebuilt tree should keep working like any other binary tree
+			err2 = back.AddNodeBFS(-1)
+			if err2 != nil {
+				t.Fatalf("AddNodeBFS() failed with error: %v", err2)
+			}
+			if back.LastLeaf().data != -1 || back.LastLeaf() != back.nodeAt(size+1) {
+				t.Errorf("AddNodeBFS() after FromCompact() gave incorrect last leaf: %v", back.LastLeaf())
+			}
+			if size > 0 && back.LastLeaf().parent != back.nodeAt((size+1)/2) {
+				t.Errorf("AddNodeBFS() after FromCompact() linked the new node to the wrong parent")
+			}
+		})
+	}
+}
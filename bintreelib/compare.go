@@ -0,0 +1,94 @@
+package bintreelib
+
+// Equal tells you if the two binary trees have exactly the same shape, with the same values in the same places
+func (bt *BinaryTree[T]) Equal(other *BinaryTree[T]) (bool, error) {
+	if bt.IsNil() || other.IsNil() {
+		return false, treeNilError
+	}
+	return equalSubtrees(bt.root, other.root), nil
+}
+
+func equalSubtrees[T comparable](a, b *Node[T]) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.data == b.data && equalSubtrees(a.left, b.left) && equalSubtrees(a.right, b.right)
+}
+
+// IsMirrorOf tells you if the other binary tree is this one reflected left to right
+func (bt *BinaryTree[T]) IsMirrorOf(other *BinaryTree[T]) (bool, error) {
+	if bt.IsNil() || other.IsNil() {
+		return false, treeNilError
+	}
+	return mirroredSubtrees(bt.root, other.root), nil
+}
+
+func mirroredSubtrees[T comparable](a, b *Node[T]) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.data == b.data && mirroredSubtrees(a.left, b.right) && mirroredSubtrees(a.right, b.left)
+}
+
+// IsIsomorphicTo tells you if the other binary tree can be turned into this one by swapping the children of some nodes
+// Both trees get canonical ids, where equal ids mean equal subtrees up to child swaps, so this runs in O(n) even with repeated values
+func (bt *BinaryTree[T]) IsIsomorphicTo(other *BinaryTree[T]) (bool, error) {
+	if bt.IsNil() || other.IsNil() {
+		return false, treeNilError
+	}
+
+	ids := canonicalIDs[T]{}
+	return ids.of(bt.root) == ids.of(other.root), nil
+}
+
+// canonicalKey describes a subtree up to child swaps: its value, and the ids of its children in sorted order
+type canonicalKey[T comparable] struct {
+	data      T
+	low, high int
+}
+
+// canonicalIDs hands out a small integer to every distinct subtree shape it sees, with 0 for the empty subtree
+type canonicalIDs[T comparable] map[canonicalKey[T]]int
+
+func (ids canonicalIDs[T]) of(node *Node[T]) int {
+	if node == nil {
+		return 0
+	}
+
+	low, high := ids.of(node.left), ids.of(node.right)
+	if low > high {
+		low, high = high, low
+	}
+
+	key := canonicalKey[T]{node.data, low, high}
+	id, present := ids[key]
+	if !present {
+		id = len(ids) + 1
+		ids[key] = id
+	}
+	return id
+}
+
+// ContainsSubtree tells you if the pattern occurs somewhere in the binary tree, as a node along with everything below it
+// It compares structural hashes at every node, and only checks the nodes in full when the hashes match, in O(n + m)
+// To look up many patterns in the same tree, build a HashIndex once instead
+func (bt *BinaryTree[T]) ContainsSubtree(pattern *BinaryTree[T]) (bool, error) {
+	if bt.IsNil() || pattern.IsNil() {
+		return false, treeNilError
+	}
+
+	if pattern.IsEmpty() {
+		return false, treeEmptyError
+	}
+
+	ids := valueIDs[T]{}
+	want := recurseMerkleHash(pattern.root, ids.of, nil)
+
+	found := false
+	recurseMerkleHash(bt.root, ids.of, func(node *Node[T], hash uint64) {
+		if !found && hash == want && equalSubtrees(node, pattern.root) {
+			found = true
+		}
+	})
+	return found, nil
+}
@@ -0,0 +1,260 @@
+package bintreelib
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestCompareTrees(t *testing.T) {
+	tests := []struct {
+		name       string
+		a, b       string
+		equal      bool
+		mirror     bool
+		isomorphic bool
+	}{
+		{"both empty", "[]", "[]", true, true, true},
+		{"empty and non empty", "[]", "[1]", false, false, false},
+		{"same tree", "[1,2,3,4]", "[1,2,3,4]", true, false, true},
+		{"mirror images", "[1,2,3,4]", "[1,3,2,null,null,null,4]", false, true, true},
+		{"symmetric tree", "[1,2,2,3,null,null,3]", "[1,2,2,3,null,null,3]", true, true, true},
+		{"swapped children deep down", "[1,2,3,4,5,6]", "[1,3,2,6,null,4,5]", false, false, true},
+		{"swap on one side only", "[1,2,3,4,5]", "[1,2,3,5,4]", false, false, true},
+		{"different values", "[1,2,3]", "[1,2,4]", false, false, false},
+		{"different shapes", "[1,2,3,4]", "[1,2,3,null,null,null,4]", false, false, false},
+		{"same values in different places", "[1,2,3,2]", "[1,2,3,null,null,2]", false, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, err := Deserialize[int](test.a)
+			if err != nil {
+				t.Fatalf("Deserialize() failed with error: %v", err)
+			}
+
+			b, err := Deserialize[int](test.b)
+			if err != nil {
+				t.Fatalf("Deserialize() failed with error: %v", err)
+			}
+
+			for _, result := range []struct {
+				method string
+				want   bool
+				got    func(other *BinaryTree[int]) (bool, error)
+			}{
+				{"Equal", test.equal, a.Equal},
+				{"IsMirrorOf", test.mirror, a.IsMirrorOf},
+				{"IsIsomorphicTo", test.isomorphic, a.IsIsomorphicTo},
+			} {
+				got, err2 := result.got(b)
+				if err2 != nil {
+					t.Fatalf("%v() failed with error: %v", result.method, err2)
+				} else if got != result.want {
+					t.Errorf("%v() returned incorrect results, want: %v, got: %v", result.method, result.want, got)
+				}
+			}
+		})
+	}
+
+	var nilTree *BinaryTree[int]
+	_, err := (&BinaryTree[int]{}).Equal(nilTree)
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("Equal() with a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestContainsSubtree(t *testing.T) {
+	//          1
+	//        /   \
+	//       2     3
+	//      / \   / \
+	//     4   5 2   6
+	//          / \
+	//         4   5
+	bt, err := Deserialize[int]("[1,2,3,4,5,2,6,null,null,null,null,4,5]")
+	if err != nil {
+		t.Fatalf("Deserialize() failed with error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    bool
+		matches int
+	}{
+		{"whole tree", "[1,2,3,4,5,2,6,null,null,null,null,4,5]", true, 1},
+		{"repeated subtree", "[2,4,5]", true, 2},
+		{"single leaf", "[6]", true, 1},
+		{"repeated leaf", "[4]", true, 2},
+		{"top part only", "[1,2,3]", false, 0},
+		{"mirrored subtree", "[2,5,4]", false, 0},
+		{"missing value", "[7]", false, 0},
+	}
+
+	index, err := bt.NewHashIndex()
+	if err != nil {
+		t.Fatalf("NewHashIndex() failed with error: %v", err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pattern, err2 := Deserialize[int](test.pattern)
+			if err2 != nil {
+				t.Fatalf("Deserialize() failed with error: %v", err2)
+			}
+
+			got, err2 := bt.ContainsSubtree(pattern)
+			if err2 != nil {
+				t.Fatalf("ContainsSubtree() failed with error: %v", err2)
+			} else if got != test.want {
+				t.Errorf("ContainsSubtree() returned incorrect results, want: %v, got: %v", test.want, got)
+			}
+
+			matches, err2 := index.FindSubtree(pattern)
+			if err2 != nil {
+				t.Fatalf("FindSubtree() failed with error: %v", err2)
+			} else if len(matches) != test.matches {
+				t.Errorf("FindSubtree() returned incorrect results, want %v matches, got: %v", test.matches, matches)
+			}
+
+			for _, match := range matches {
+				if !equalSubtrees(match, pattern.root) {
+					t.Errorf("FindSubtree() returned a node that does not match the pattern: %v", match)
+				}
+			}
+		})
+	}
+
+	_, err = bt.ContainsSubtree(&BinaryTree[int]{})
+	if !errors.Is(err, treeEmptyError) {
+		t.Errorf("ContainsSubtree() with an empty pattern should have failed with error: %v, got: %v", treeEmptyError, err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestContainsSubtreeEqualValues(t *testing.T) {
+	// -0.0 == 0.0, even though they print differently, so the hashes have to treat them as the same value
+	negativeZero := math.Copysign(0, -1)
+	bt, err := ConstructFromValues(1.0, negativeZero, 2.0)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	pattern, err := ConstructFromValues(0.0)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	found, err := bt.ContainsSubtree(pattern)
+	if err != nil {
+		t.Fatalf("ContainsSubtree() failed with error: %v", err)
+	} else if !found {
+		t.Errorf("ContainsSubtree() should have found 0.0 in a tree holding -0.0")
+	}
+
+	index, err := bt.NewHashIndex()
+	if err != nil {
+		t.Fatalf("NewHashIndex() failed with error: %v", err)
+	}
+
+	matches, err := index.FindSubtree(pattern)
+	if err != nil {
+		t.Fatalf("FindSubtree() failed with error: %v", err)
+	} else if len(matches) != 1 || matches[0] != bt.Root().left {
+		t.Errorf("FindSubtree() returned incorrect results, want: %v, got: %v", []*Node[float64]{bt.Root().left}, matches)
+	}
+
+	// the same goes for -0.0 inside a struct value
+	type point struct{ x, y float64 }
+	points, err := ConstructFromValues(point{1, negativeZero}, point{2, 0})
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	found, err = points.ContainsSubtree(&BinaryTree[point]{root: &Node[point]{data: point{1, 0}, left: &Node[point]{data: point{2, 0}}}})
+	if err != nil {
+		t.Fatalf("ContainsSubtree() failed with error: %v", err)
+	} else if !found {
+		t.Errorf("ContainsSubtree() should have matched the whole tree")
+	}
+}
+
+func TestHashIndex(t *testing.T) {
+	bt, err := Deserialize[string](`["a","b","b","c","d","c","d",null,null,null,null,"c"]`)
+	if err != nil {
+		t.Fatalf("Deserialize() failed with error: %v", err)
+	}
+
+	index, err := bt.NewHashIndex()
+	if err != nil {
+		t.Fatalf("NewHashIndex() failed with error: %v", err)
+	}
+
+	// equal subtrees share a hash, and different ones (almost always) don't
+	left, right := bt.Root().left, bt.Root().right
+	leftHash, err := index.Hash(left)
+	if err != nil {
+		t.Fatalf("Hash() failed with error: %v", err)
+	}
+
+	rightHash, err := index.Hash(right)
+	if err != nil {
+		t.Fatalf("Hash() failed with error: %v", err)
+	}
+
+	cHash, err := index.Hash(left.left)
+	if err != nil {
+		t.Fatalf("Hash() failed with error: %v", err)
+	}
+
+	otherCHash, err := index.Hash(right.left.left)
+	if err != nil {
+		t.Fatalf("Hash() failed with error: %v", err)
+	}
+
+	if leftHash == rightHash {
+		t.Errorf("the subtrees under b differ, but got the same hash")
+	}
+	if cHash != otherCHash {
+		t.Errorf("the leaves holding c are equal, but got different hashes")
+	}
+
+	// the leaves c and d show up twice each, while the b subtrees differ (the right one has an extra c)
+	groups, err := index.DuplicateSubtrees()
+	if err != nil {
+		t.Fatalf("DuplicateSubtrees() failed with error: %v", err)
+	}
+
+	got := []string{}
+	for _, group := range groups {
+		got = append(got, fmt.Sprintf("%v x%v", group[0], len(group)))
+	}
+
+	want := []string{"c x2", "d x2"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("DuplicateSubtrees() returned incorrect results, want: %v, got: %v", want, got)
+	}
+
+	other, err := ConstructFromValues("a")
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	_, err = index.Hash(other.Root())
+	if !errors.Is(err, nodeNotInTreeError) {
+		t.Errorf("Hash() of a node of another tree should have failed with error: %v, got: %v", nodeNotInTreeError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	var nilIndex *HashIndex[string]
+	_, err = nilIndex.DuplicateSubtrees()
+	if !errors.Is(err, hashIndexNilError) {
+		t.Errorf("DuplicateSubtrees() on a nil index should have failed with error: %v, got: %v", hashIndexNilError, err)
+	}
+}
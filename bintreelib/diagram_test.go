@@ -0,0 +1,92 @@
+package bintreelib
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestExportDOT(t *testing.T) {
+	bt, err := Deserialize[int]("[1,2,3,null,4]")
+	if err != nil {
+		t.Fatalf("Deserialize() failed with error: %v", err)
+	}
+
+	highlight := func(node *Node[int]) NodeAttributes {
+		if node.data == 4 {
+			return NodeAttributes{"color": "red"}
+		}
+		return nil
+	}
+
+	got, err := bt.ExportDOT(highlight)
+	if err != nil {
+		t.Fatalf("ExportDOT() failed with error: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"digraph {",
+		"\tgraph [ordering=out];",
+		"\tn0 [label=\"1\"];",
+		"\tn0 -> n1 [label=L];",
+		"\tn1 [label=\"2\"];",
+		"\tnil2 [style=invis];",
+		"\tn1 -> nil2 [style=invis];",
+		"\tn1 -> n3 [label=R];",
+		"\tn3 [label=\"4\", \"color\"=\"red\"];",
+		"\tn0 -> n4 [label=R];",
+		"\tn4 [label=\"3\"];",
+		"}",
+	}, "\n") + "\n"
+	if got != want {
+		t.Errorf("ExportDOT() returned incorrect results, want:\n%v\ngot:\n%v", want, got)
+	}
+}
+
+func TestExportMermaid(t *testing.T) {
+	bt, err := Deserialize[string](`["a","b",null,"c"]`)
+	if err != nil {
+		t.Fatalf("Deserialize() failed with error: %v", err)
+	}
+
+	got, err := bt.ExportMermaid(nil)
+	if err != nil {
+		t.Fatalf("ExportMermaid() failed with error: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"graph TD",
+		"\tn0[\"a\"]",
+		"\tn0 -->|L| n1",
+		"\tn1[\"b\"]",
+		"\tn1 -->|L| n2",
+		"\tn2[\"c\"]",
+		"\tnil3[\" \"]:::invisible",
+		"\tn1 ~~~ nil3",
+		"\tnil4[\" \"]:::invisible",
+		"\tn0 ~~~ nil4",
+		"\tclassDef invisible display:none;",
+	}, "\n") + "\n"
+	if got != want {
+		t.Errorf("ExportMermaid() returned incorrect results, want:\n%v\ngot:\n%v", want, got)
+	}
+}
+
+func TestExportErrors(t *testing.T) {
+	var nilTree *BinaryTree[int]
+
+	_, err := nilTree.ExportDOT(nil)
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("ExportDOT() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	_, err = (&BinaryTree[int]{}).ExportMermaid(nil)
+	if !errors.Is(err, treeEmptyError) {
+		t.Errorf("ExportMermaid() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	} else {
+		fmt.Println(err)
+	}
+}
@@ -0,0 +1,155 @@
+package bintreelib
+
+import (
+	"fmt"
+)
+
+var nodeNotInTreeError = fmt.Errorf("the node is not part of the binary tree")
+var subtreeEmptyError = fmt.Errorf("the subtree to graft is nil or empty")
+
+// Side picks one of the two child slots of a node
+type Side int
+
+const (
+	Left Side = iota
+	Right
+)
+
+// Side's implementation of the fmt.Stringer interface
+func (side Side) String() string {
+	switch side {
+	case Left:
+		return "left"
+	case Right:
+		return "right"
+	}
+	return "unknown side"
+}
+
+// childSlot returns a pointer to the child field of the node on the given side
+func (node *Node[T]) childSlot(side Side) (**Node[T], error) {
+	switch side {
+	case Left:
+		return &node.left, nil
+	case Right:
+		return &node.right, nil
+	}
+	return nil, fmt.Errorf("invalid side: %v", int(side))
+}
+
+// SetLeft adds a new node holding the given value as the left child of the parent node, and returns it
+// The parent has to belong to this tree and must not have a left child already
+// The count, last leaf and completeness of the tree are worked out again with a BFS right away, so each edit is O(n)
+func (bt *BinaryTree[T]) SetLeft(parent *Node[T], val T) (*Node[T], error) {
+	return bt.setChild(parent, Left, val)
+}
+
+// SetRight adds a new node holding the given value as the right child of the parent node, and returns it
+// The parent has to belong to this tree and must not have a right child already, and like SetLeft it is O(n)
+func (bt *BinaryTree[T]) SetRight(parent *Node[T], val T) (*Node[T], error) {
+	return bt.setChild(parent, Right, val)
+}
+
+func (bt *BinaryTree[T]) setChild(parent *Node[T], side Side, val T) (*Node[T], error) {
+	slot, err := bt.freeChildSlot(parent, side)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &Node[T]{data: val, parent: parent}
+	*slot = node
+
+	err = bt.refreshShape()
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// DetachSubtree cuts the subtree rooted at the given node out of the tree, and returns it as a binary tree of its own
+// Detaching the root leaves this tree empty. Both trees get their shape worked out again, in O(n)
+func (bt *BinaryTree[T]) DetachSubtree(node *Node[T]) (*BinaryTree[T], error) {
+	if bt.IsNil() {
+		return nil, treeNilError
+	}
+
+	if node == nil {
+		return nil, nodeNilError
+	}
+
+	if !bt.owns(node) {
+		return nil, nodeNotInTreeError
+	}
+
+	if node == bt.root {
+		bt.root = nil
+	} else if node.parent.left == node {
+		node.parent.left = nil
+	} else {
+		node.parent.right = nil
+	}
+	node.parent = nil
+
+	err := bt.refreshShape()
+	if err != nil {
+		return nil, err
+	}
+	return newShapedTree(node)
+}
+
+// GraftSubtree attaches the whole of the given subtree as a child of the parent node, on the given side
+// The child slot has to be free. The nodes move over to this tree, which leaves the subtree empty
+// This tree gets its shape worked out again with a BFS, in O(n)
+func (bt *BinaryTree[T]) GraftSubtree(parent *Node[T], side Side, subtree *BinaryTree[T]) error {
+	if subtree.IsEmpty() {
+		return subtreeEmptyError
+	}
+
+	slot, err := bt.freeChildSlot(parent, side)
+	if err != nil {
+		return err
+	}
+
+	// grafting nodes of this very tree would make the parent its own descendant
+	if bt.owns(subtree.root) {
+		return fmt.Errorf("can't graft a binary tree onto itself")
+	}
+
+	*slot = subtree.root
+	subtree.root.parent = parent
+	*subtree = BinaryTree[T]{}
+
+	return bt.refreshShape()
+}
+
+// freeChildSlot checks that the parent node belongs to the tree and has no child on the given side,
+// and returns a pointer to that child field
+func (bt *BinaryTree[T]) freeChildSlot(parent *Node[T], side Side) (**Node[T], error) {
+	if bt.IsNil() {
+		return nil, treeNilError
+	}
+
+	if parent == nil {
+		return nil, nodeNilError
+	}
+
+	slot, err := parent.childSlot(side)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bt.owns(parent) {
+		return nil, nodeNotInTreeError
+	}
+
+	if *slot != nil {
+		return nil, fmt.Errorf("the node %v already has a %v child: %v", parent, side, *slot)
+	}
+
+	return slot, nil
+}
+
+// owns tells you if the node belongs to this tree, by following the parent pointers up to the root, in O(h)
+func (bt *BinaryTree[T]) owns(node *Node[T]) bool {
+	return node != nil && bt.depthOf(node) != invalidCount
+}
@@ -0,0 +1,276 @@
+package bintreelib
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSetLeftAndSetRight(t *testing.T) {
+	// build the parse tree of (1 + 2) * 3 by hand
+	bt, err := ConstructFromValues("*")
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	plus, err := bt.SetLeft(bt.Root(), "+")
+	if err != nil {
+		t.Fatalf("SetLeft() failed with error: %v", err)
+	}
+
+	_, err = bt.SetRight(bt.Root(), "3")
+	if err != nil {
+		t.Fatalf("SetRight() failed with error: %v", err)
+	}
+
+	_, err = bt.SetRight(plus, "2")
+	if err != nil {
+		t.Fatalf("SetRight() failed with error: %v", err)
+	}
+
+	// with only a right child under +, the tree is no longer complete
+	cnt, err := bt.Count()
+	if err != nil {
+		t.Fatalf("Count() failed with error: %v", err)
+	} else if cnt != 4 {
+		t.Errorf("Count() returned incorrect results, want: %v, got: %v", 4, cnt)
+	}
+
+	if bt.LastLeaf().data != "2" || !bt.incomplete {
+		t.Errorf("the shape of the tree was not updated, last leaf: %v, incomplete: %v", bt.LastLeaf(), bt.incomplete)
+	}
+
+	_, err = bt.SetLeft(plus, "1")
+	if err != nil {
+		t.Fatalf("SetLeft() failed with error: %v", err)
+	}
+	checkNodeLinks(t, bt)
+
+	want := []string{"1", "+", "2", "*", "3"}
+	if got := slices.Collect(bt.InOrder()); !slices.Equal(got, want) {
+		t.Errorf("InOrder() returned incorrect results, want: %v, got: %v", want, got)
+	}
+
+	if bt.LastLeaf().data != "2" || bt.incomplete {
+		t.Errorf("the shape of the tree was not updated, last leaf: %v, incomplete: %v", bt.LastLeaf(), bt.incomplete)
+	}
+
+	// the slot is taken now
+	_, err = bt.SetLeft(plus, "0")
+	if err == nil {
+		t.Errorf("SetLeft() on an occupied slot should have failed")
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestEditErrors(t *testing.T) {
+	bt, err := ConstructFromValues(1, 2, 3)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	other, err := ConstructFromValues(4, 5)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	// RemoveValue moves the last leaf's value into the root, and takes the last leaf's node out of the tree
+	removedTree, err := ConstructFromValues(6, 7, 8, 9)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+	removed := removedTree.LastLeaf()
+	err = removedTree.RemoveValue(6)
+	if err != nil {
+		t.Fatalf("RemoveValue() failed with error: %v", err)
+	}
+
+	var nilTree *BinaryTree[int]
+
+	tests := []struct {
+		name    string
+		edit    func() error
+		wantErr error
+	}{
+		{"SetLeft under a removed node", func() error { _, e := removedTree.SetLeft(removed, 0); return e }, nodeNotInTreeError},
+		{"SetRight under a removed node", func() error { _, e := removedTree.SetRight(removed, 0); return e }, nodeNotInTreeError},
+		{"DetachSubtree of a removed node", func() error { _, e := removedTree.DetachSubtree(removed); return e }, nodeNotInTreeError},
+		{"GraftSubtree under a removed node", func() error { return removedTree.GraftSubtree(removed, Left, other) }, nodeNotInTreeError},
+		{"SetLeft on a nil tree", func() error { _, e := nilTree.SetLeft(bt.Root(), 0); return e }, treeNilError},
+		{"SetLeft under a nil node", func() error { _, e := bt.SetLeft(nil, 0); return e }, nodeNilError},
+		{"SetRight under a node of another tree", func() error { _, e := bt.SetRight(other.Root(), 0); return e }, nodeNotInTreeError},
+		{"DetachSubtree on a nil tree", func() error { _, e := nilTree.DetachSubtree(bt.Root()); return e }, treeNilError},
+		{"DetachSubtree of a nil node", func() error { _, e := bt.DetachSubtree(nil); return e }, nodeNilError},
+		{"DetachSubtree of a node of another tree", func() error { _, e := bt.DetachSubtree(other.Root()); return e }, nodeNotInTreeError},
+		{"GraftSubtree of a nil tree", func() error { return bt.GraftSubtree(bt.LastLeaf(), Left, nil) }, subtreeEmptyError},
+		{"GraftSubtree of an empty tree", func() error { return bt.GraftSubtree(bt.LastLeaf(), Left, &BinaryTree[int]{}) }, subtreeEmptyError},
+		{"GraftSubtree under a node of another tree", func() error { return bt.GraftSubtree(other.LastLeaf(), Left, other) }, nodeNotInTreeError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err2 := test.edit()
+			if !errors.Is(err2, test.wantErr) {
+				t.Errorf("the edit should have failed with error: %v, got: %v", test.wantErr, err2)
+			} else {
+				fmt.Println(err2)
+			}
+		})
+	}
+
+	for _, test := range []struct {
+		name string
+		edit func() error
+	}{
+		{"GraftSubtree on an occupied slot", func() error { return bt.GraftSubtree(bt.Root(), Left, other) }},
+		{"GraftSubtree of a tree onto itself", func() error { return bt.GraftSubtree(bt.LastLeaf(), Left, bt) }},
+		{"GraftSubtree on an invalid side", func() error { return bt.GraftSubtree(bt.LastLeaf(), Side(5), other) }},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err2 := test.edit()
+			if err2 == nil {
+				t.Errorf("the edit should have failed")
+			} else {
+				fmt.Println(err2)
+			}
+		})
+	}
+
+	// none of the failed edits should have changed the other tree
+	if got := slices.Collect(other.BFS()); !slices.Equal(got, []int{4, 5}) {
+		t.Errorf("failed edits changed the other tree, got: %v", got)
+	}
+	if got := slices.Collect(removedTree.BFS()); !slices.Equal(got, []int{9, 7, 8}) {
+		t.Errorf("failed edits changed the tree with the removed node, got: %v", got)
+	}
+}
+
+func TestDetachAndGraftSubtree(t *testing.T) {
+	bt, err := ConstructFromValues(1, 2, 3, 4, 5, 6, 7)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	two := bt.Root().left
+	three := bt.Root().right
+
+	detached, err := bt.DetachSubtree(two)
+	if err != nil {
+		t.Fatalf("DetachSubtree() failed with error: %v", err)
+	}
+	checkNodeLinks(t, bt)
+	checkNodeLinks(t, detached)
+
+	if got := slices.Collect(bt.BFS()); !slices.Equal(got, []int{1, 3, 6, 7}) {
+		t.Errorf("DetachSubtree() left incorrect results, want: %v, got: %v", []int{1, 3, 6, 7}, got)
+	}
+	if got := slices.Collect(detached.BFS()); !slices.Equal(got, []int{2, 4, 5}) {
+		t.Errorf("DetachSubtree() returned incorrect results, want: %v, got: %v", []int{2, 4, 5}, got)
+	}
+
+	cnt, err := bt.Count()
+	if err != nil {
+		t.Fatalf("Count() failed with error: %v", err)
+	} else if cnt != 4 || bt.LastLeaf().data != 7 || !bt.incomplete {
+		t.Errorf("DetachSubtree() did not update the shape, count: %v, last leaf: %v, incomplete: %v", cnt, bt.LastLeaf(), bt.incomplete)
+	}
+
+	cnt, err = detached.Count()
+	if err != nil {
+		t.Fatalf("Count() failed with error: %v", err)
+	} else if cnt != 3 || detached.LastLeaf().data != 5 || detached.incomplete {
+		t.Errorf("the detached tree has the wrong shape, count: %v, last leaf: %v, incomplete: %v", cnt, detached.LastLeaf(), detached.incomplete)
+	}
+
+	// move the detached subtree under 7, on its right
+	seven := three.right
+	err = bt.GraftSubtree(seven, Right, detached)
+	if err != nil {
+		t.Fatalf("GraftSubtree() failed with error: %v", err)
+	}
+	checkNodeLinks(t, bt)
+
+	if !detached.IsEmpty() {
+		t.Errorf("GraftSubtree() should have emptied the grafted tree")
+	}
+
+	want := []int{1, 3, 6, 7, 2, 4, 5}
+	if got := slices.Collect(bt.BFS()); !slices.Equal(got, want) {
+		t.Errorf("GraftSubtree() gave incorrect results, want: %v, got: %v", want, got)
+	}
+
+	cnt, err = bt.Count()
+	if err != nil {
+		t.Fatalf("Count() failed with error: %v", err)
+	} else if cnt != 7 || bt.LastLeaf().data != 5 {
+		t.Errorf("GraftSubtree() did not update the shape, count: %v, last leaf: %v", cnt, bt.LastLeaf())
+	}
+
+	// the edited tree still supports removals, which always take out the last node in breadth first order
+	err = bt.RemoveValue(1)
+	if err != nil {
+		t.Fatalf("RemoveValue() failed with error: %v", err)
+	}
+	checkNodeLinks(t, bt)
+
+	want = []int{5, 3, 6, 7, 2, 4}
+	if got := slices.Collect(bt.BFS()); !slices.Equal(got, want) {
+		t.Errorf("RemoveValue() gave incorrect results, want: %v, got: %v", want, got)
+	}
+
+	// detaching the root empties the tree
+	whole, err := bt.DetachSubtree(bt.Root())
+	if err != nil {
+		t.Fatalf("DetachSubtree() failed with error: %v", err)
+	}
+
+	if !bt.IsEmpty() || bt.LastLeaf() != nil {
+		t.Errorf("DetachSubtree() of the root should have emptied the tree")
+	}
+
+	cnt, err = whole.Count()
+	if err != nil {
+		t.Fatalf("Count() failed with error: %v", err)
+	} else if cnt != 6 {
+		t.Errorf("Count() returned incorrect results, want: %v, got: %v", 6, cnt)
+	}
+}
+
+func TestReadsAfterEdits(t *testing.T) {
+	bt, err := ConstructFromValues(1, 2, 3)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	_, err = bt.SetRight(bt.Root().left, 4)
+	if err != nil {
+		t.Fatalf("SetRight() failed with error: %v", err)
+	}
+
+	// the edit already updated the shape, so concurrent readers don't write to the tree
+	wg := sync.WaitGroup{}
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			cnt, err2 := bt.Count()
+			if err2 != nil || cnt != 4 {
+				t.Errorf("Count() returned incorrect results, want: %v, got: %v (error: %v)", 4, cnt, err2)
+			}
+
+			complete, err2 := bt.IsComplete()
+			if err2 != nil || complete {
+				t.Errorf("IsComplete() returned incorrect results, want: %v, got: %v (error: %v)", false, complete, err2)
+			}
+
+			if leaf := bt.LastLeaf(); leaf == nil || leaf.data != 4 {
+				t.Errorf("LastLeaf() returned incorrect results, want: %v, got: %v", 4, leaf)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,173 @@
+package bintreelib
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+)
+
+var hashIndexNilError = fmt.Errorf("the hash index is nil")
+
+// nilHash stands in for a missing child in the structural hashes
+const nilHash uint64 = 0x9e3779b97f4a7c15
+
+// valueIDs hands out a small integer to every distinct value it sees, with 0 left for a value it has not seen
+// Values that are == always get the same id, which their formatted text does not promise
+// (-0.0 and 0.0 print differently, and so do interfaces holding equal values of different named types)
+type valueIDs[T comparable] map[T]uint64
+
+// of returns the id of the value, handing out a new one if the value has not been seen yet
+func (ids valueIDs[T]) of(val T) uint64 {
+	id, present := ids[val]
+	if !present {
+		id = uint64(len(ids)) + 1
+		ids[val] = id
+	}
+	return id
+}
+
+// lookup returns the id of the value, or 0 if the value has not been seen, without handing out a new one
+func (ids valueIDs[T]) lookup(val T) uint64 {
+	return ids[val]
+}
+
+// recurseMerkleHash returns the structural (Merkle) hash of the subtree rooted at the node,
+// which mixes the id of the node's value with the hashes of its two children, so equal subtrees always get equal hashes
+// as long as their values get their ids from the same valueIDs
+// The visit function, when given, is called with every node and its hash, children first
+func recurseMerkleHash[T comparable](node *Node[T], valueID func(T) uint64, visit func(node *Node[T], hash uint64)) uint64 {
+	if node == nil {
+		return nilHash
+	}
+
+	left := recurseMerkleHash(node.left, valueID, visit)
+	right := recurseMerkleHash(node.right, valueID, visit)
+
+	var buf [24]byte
+	binary.LittleEndian.PutUint64(buf[:8], valueID(node.data))
+	binary.LittleEndian.PutUint64(buf[8:16], left)
+	binary.LittleEndian.PutUint64(buf[16:], right)
+
+	hasher := fnv.New64a()
+	hasher.Write(buf[:])
+
+	hash := hasher.Sum64()
+	if visit != nil {
+		visit(node, hash)
+	}
+	return hash
+}
+
+// HashIndex keeps the structural hash of every node of a snapshot of a binary tree,
+// so that subtree lookups and duplicate subtree detection don't have to walk the whole tree each time
+// Different subtrees can share a hash, so every match is confirmed by comparing the subtrees in full
+// The index does not follow later changes to the tree, so build a new one after editing it
+type HashIndex[T comparable] struct {
+	ids       valueIDs[T]
+	hashes    map[*Node[T]]uint64
+	buckets   map[uint64][]*Node[T] // the nodes with each hash, in post-order
+	postOrder []*Node[T]
+}
+
+// NewHashIndex walks the binary tree once to work out the structural hash of every node
+func (bt *BinaryTree[T]) NewHashIndex() (*HashIndex[T], error) {
+	if bt.IsNil() {
+		return nil, treeNilError
+	}
+
+	index := &HashIndex[T]{ids: valueIDs[T]{}, hashes: map[*Node[T]]uint64{}, buckets: map[uint64][]*Node[T]{}}
+	recurseMerkleHash(bt.root, index.ids.of, func(node *Node[T], hash uint64) {
+		index.hashes[node] = hash
+		index.buckets[hash] = append(index.buckets[hash], node)
+		index.postOrder = append(index.postOrder, node)
+	})
+	return index, nil
+}
+
+// Hash returns the structural hash of the subtree rooted at the given node
+// The hash depends on the ids the index gave the values, so only compare it with other hashes from the same index
+func (index *HashIndex[T]) Hash(node *Node[T]) (uint64, error) {
+	if index == nil {
+		return 0, hashIndexNilError
+	}
+
+	if node == nil {
+		return 0, nodeNilError
+	}
+
+	hash, present := index.hashes[node]
+	if !present {
+		return 0, nodeNotInTreeError
+	}
+	return hash, nil
+}
+
+// FindSubtree returns every node (in post-order) whose subtree is identical to the pattern
+// Only the nodes sharing the pattern's hash get compared, so a lookup costs O(m) for a pattern with m nodes, plus the matches
+func (index *HashIndex[T]) FindSubtree(pattern *BinaryTree[T]) ([]*Node[T], error) {
+	if index == nil {
+		return nil, hashIndexNilError
+	}
+
+	if pattern.IsNil() {
+		return nil, treeNilError
+	}
+
+	if pattern.IsEmpty() {
+		return nil, treeEmptyError
+	}
+
+	// a pattern value missing from the tree gets the id 0, so the pattern can't match, and any node sharing its hash is ruled out below
+	matches := []*Node[T]{}
+	for _, node := range index.buckets[recurseMerkleHash(pattern.root, index.ids.lookup, nil)] {
+		if equalSubtrees(node, pattern.root) {
+			matches = append(matches, node)
+		}
+	}
+	return matches, nil
+}
+
+// DuplicateSubtrees returns the groups of nodes whose subtrees are identical, for every subtree that occurs more than once
+// The nodes in each group are in post-order
+func (index *HashIndex[T]) DuplicateSubtrees() ([][]*Node[T], error) {
+	if index == nil {
+		return nil, hashIndexNilError
+	}
+
+	groups := [][]*Node[T]{}
+	done := map[uint64]bool{}
+
+	// go through the buckets in the post-order of their first node, so the groups come out in a fixed order
+	for _, first := range index.postOrder {
+		hash := index.hashes[first]
+		bucket := index.buckets[hash]
+		if done[hash] || len(bucket) < 2 {
+			continue
+		}
+		done[hash] = true
+
+		// split the bucket into groups of truly equal subtrees, in case different subtrees share the hash
+		var bucketGroups [][]*Node[T]
+		for _, node := range bucket {
+			placed := false
+			for i, group := range bucketGroups {
+				if equalSubtrees(group[0], node) {
+					bucketGroups[i] = append(group, node)
+					placed = true
+					break
+				}
+			}
+			if !placed {
+				bucketGroups = append(bucketGroups, []*Node[T]{node})
+			}
+		}
+
+		for _, group := range bucketGroups {
+			if len(group) > 1 {
+				groups = append(groups, group)
+			}
+		}
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,250 @@
+package bintreelib
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+
+	"github.com/pluckynumbat/go-tree/internal/ordering"
+)
+
+var heapNilError = fmt.Errorf("the heap is nil")
+var heapEmptyError = fmt.Errorf("the heap is empty")
+var noComparatorError = fmt.Errorf("the heap has no comparator for its element type")
+
+// Heap is a binary heap (priority queue), stored as a complete binary tree in an array layout,
+// where the children of the value at index i are at 2i+1 and 2i+2, and its parent is at (i-1)/2
+// The value at the top is the one that compares lowest, so a reversed comparator gives a max heap
+// The zero value is a min heap for element types with a natural ordering
+type Heap[T any] struct {
+	values  []T
+	compare func(a, b T) int
+}
+
+// NewMinHeap returns an empty heap whose top is always the smallest value
+func NewMinHeap[T cmp.Ordered]() *Heap[T] {
+	return &Heap[T]{compare: cmp.Compare[T]}
+}
+
+// NewMaxHeap returns an empty heap whose top is always the largest value
+func NewMaxHeap[T cmp.Ordered]() *Heap[T] {
+	return &Heap[T]{compare: func(a, b T) int { return cmp.Compare(b, a) }}
+}
+
+// NewHeap returns an empty heap ordered by the given comparator, with the lowest value at the top
+// The comparator should return a negative number when a < b, zero when a == b and a positive number when a > b
+func NewHeap[T any](compare func(a, b T) int) *Heap[T] {
+	return &Heap[T]{compare: compare}
+}
+
+// comparator returns the function used to order the values of the heap,
+// falling back to the natural ordering of the element type when the heap was not given one
+func (h *Heap[T]) comparator() (func(a, b T) int, error) {
+	if h.compare != nil {
+		return h.compare, nil
+	}
+
+	compare := ordering.Comparator[T]()
+	if compare == nil {
+		return nil, noComparatorError
+	}
+
+	return compare, nil
+}
+
+// IsNil tells you if this pointer to the Heap is nil
+func (h *Heap[T]) IsNil() bool {
+	return h == nil
+}
+
+// IsEmpty checks whether a Heap is empty
+func (h *Heap[T]) IsEmpty() bool {
+	return h.IsNil() || len(h.values) == 0
+}
+
+// Len returns the number of values in the Heap
+func (h *Heap[T]) Len() (int, error) {
+	if h.IsNil() {
+		return invalidCount, heapNilError
+	}
+	return len(h.values), nil
+}
+
+// Push adds a value to the heap, in O(log n)
+func (h *Heap[T]) Push(val T) error {
+	if h.IsNil() {
+		return heapNilError
+	}
+
+	compare, err := h.comparator()
+	if err != nil {
+		return err
+	}
+
+	h.values = append(h.values, val)
+	h.siftUp(len(h.values)-1, compare)
+	return nil
+}
+
+// Peek returns the value at the top of the heap, without removing it
+func (h *Heap[T]) Peek() (T, error) {
+	var zero T
+	if h.IsNil() {
+		return zero, heapNilError
+	}
+
+	if h.IsEmpty() {
+		return zero, heapEmptyError
+	}
+
+	return h.values[0], nil
+}
+
+// Pop removes and returns the value at the top of the heap, in O(log n)
+func (h *Heap[T]) Pop() (T, error) {
+	var zero T
+	if h.IsNil() {
+		return zero, heapNilError
+	}
+
+	if h.IsEmpty() {
+		return zero, heapEmptyError
+	}
+
+	compare, err := h.comparator()
+	if err != nil {
+		return zero, err
+	}
+
+	top := h.values[0]
+
+	// move the last value to the top, then let it sink into place
+	last := len(h.values) - 1
+	h.values[0] = h.values[last]
+	h.values[last] = zero
+	h.values = h.values[:last]
+	h.siftDown(0, compare)
+
+	return top, nil
+}
+
+// Fix restores the heap order after the value at the given index (its position in the All() order) was changed in place
+// It is the same as removing that value and pushing the new one, but cheaper
+func (h *Heap[T]) Fix(index int) error {
+	if h.IsNil() {
+		return heapNilError
+	}
+
+	if index < 0 || index >= len(h.values) {
+		return fmt.Errorf("the index %v is out of range for a heap with %v values", index, len(h.values))
+	}
+
+	compare, err := h.comparator()
+	if err != nil {
+		return err
+	}
+
+	if !h.siftDown(index, compare) {
+		h.siftUp(index, compare)
+	}
+	return nil
+}
+
+// Update replaces the value at the given index (its position in the All() order) and restores the heap order
+func (h *Heap[T]) Update(index int, val T) error {
+	if h.IsNil() {
+		return heapNilError
+	}
+
+	if index < 0 || index >= len(h.values) {
+		return fmt.Errorf("the index %v is out of range for a heap with %v values", index, len(h.values))
+	}
+
+	h.values[index] = val
+	return h.Fix(index)
+}
+
+// Heapify replaces the contents of the heap with the given values, arranging them into heap order in O(n)
+// The heap takes ownership of the values slice
+func (h *Heap[T]) Heapify(values []T) error {
+	if h.IsNil() {
+		return heapNilError
+	}
+
+	compare, err := h.comparator()
+	if err != nil {
+		return err
+	}
+
+	h.values = values
+
+	// every index past len/2 is a leaf, which is already a valid heap on its own
+	for i := len(h.values)/2 - 1; i >= 0; i-- {
+		h.siftDown(i, compare)
+	}
+	return nil
+}
+
+// All returns an iterator over the values of the heap in their array (breadth first) order, which is not sorted
+func (h *Heap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if h.IsEmpty() {
+			return
+		}
+
+		for _, v := range h.values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops the values off the heap one by one, in sorted order
+// Breaking out of the range loop leaves the remaining values in the heap
+func (h *Heap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for !h.IsEmpty() {
+			top, err := h.Pop()
+			if err != nil || !yield(top) {
+				return
+			}
+		}
+	}
+}
+
+// siftUp moves the value at the given index towards the top, while it compares lower than its parent
+func (h *Heap[T]) siftUp(index int, compare func(a, b T) int) {
+	for index > 0 {
+		parent := (index - 1) / 2
+		if compare(h.values[index], h.values[parent]) >= 0 {
+			break
+		}
+		h.values[index], h.values[parent] = h.values[parent], h.values[index]
+		index = parent
+	}
+}
+
+// siftDown moves the value at the given index towards the leaves, while one of its children compares lower than it
+// It reports whether the value moved at all
+func (h *Heap[T]) siftDown(index int, compare func(a, b T) int) bool {
+	start := index
+	for {
+		lowest := index
+		left, right := 2*index+1, 2*index+2
+
+		if left < len(h.values) && compare(h.values[left], h.values[lowest]) < 0 {
+			lowest = left
+		}
+		if right < len(h.values) && compare(h.values[right], h.values[lowest]) < 0 {
+			lowest = right
+		}
+
+		if lowest == index {
+			return index != start
+		}
+
+		h.values[index], h.values[lowest] = h.values[lowest], h.values[index]
+		index = lowest
+	}
+}
@@ -0,0 +1,259 @@
+package bintreelib
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// checkHeapOrder verifies that no value in the heap compares lower than its parent
+func checkHeapOrder[T any](t *testing.T, h *Heap[T]) {
+	t.Helper()
+
+	compare, err := h.comparator()
+	if err != nil {
+		t.Fatalf("comparator() failed with error: %v", err)
+	}
+
+	for i := 1; i < len(h.values); i++ {
+		if compare(h.values[i], h.values[(i-1)/2]) < 0 {
+			t.Fatalf("heap order broken at index %v, value: %v, parent: %v", i, h.values[i], h.values[(i-1)/2])
+		}
+	}
+}
+
+func TestHeapNilAndEmpty(t *testing.T) {
+	var nilHeap *Heap[int]
+
+	if err := nilHeap.Push(1); !errors.Is(err, heapNilError) {
+		t.Errorf("Push() on a nil heap should have failed with error: %v, got: %v", heapNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	if _, err := nilHeap.Len(); !errors.Is(err, heapNilError) {
+		t.Errorf("Len() on a nil heap should have failed with error: %v, got: %v", heapNilError, err)
+	}
+
+	if err := nilHeap.Heapify([]int{1}); !errors.Is(err, heapNilError) {
+		t.Errorf("Heapify() on a nil heap should have failed with error: %v, got: %v", heapNilError, err)
+	}
+
+	if err := nilHeap.Fix(0); !errors.Is(err, heapNilError) {
+		t.Errorf("Fix() on a nil heap should have failed with error: %v, got: %v", heapNilError, err)
+	}
+
+	h := NewMinHeap[int]()
+
+	if _, err := h.Peek(); !errors.Is(err, heapEmptyError) {
+		t.Errorf("Peek() on an empty heap should have failed with error: %v, got: %v", heapEmptyError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	if _, err := h.Pop(); !errors.Is(err, heapEmptyError) {
+		t.Errorf("Pop() on an empty heap should have failed with error: %v, got: %v", heapEmptyError, err)
+	}
+
+	if err := h.Fix(0); err == nil {
+		t.Errorf("Fix() on an empty heap should have failed")
+	} else {
+		fmt.Println(err)
+	}
+
+	type point struct{ x, y int }
+	if err := (&Heap[point]{}).Push(point{1, 2}); !errors.Is(err, noComparatorError) {
+		t.Errorf("Push() without a comparator should have failed with error: %v, got: %v", noComparatorError, err)
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestHeapPushPop(t *testing.T) {
+	values := []int{5, 3, 8, 1, 9, 2, 7, 3, 6, 4}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	reversed := slices.Clone(sorted)
+	slices.Reverse(reversed)
+
+	tests := []struct {
+		name string
+		heap *Heap[int]
+		want []int
+	}{
+		{"min heap", NewMinHeap[int](), sorted},
+		{"max heap", NewMaxHeap[int](), reversed},
+		{"zero value heap", &Heap[int]{}, sorted},
+		{"custom comparator", NewHeap(func(a, b int) int { return cmp.Compare(b%5, a%5) }), nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, v := range values {
+				err := test.heap.Push(v)
+				if err != nil {
+					t.Fatalf("Push() failed with error: %v", err)
+				}
+				checkHeapOrder(t, test.heap)
+			}
+
+			n, err := test.heap.Len()
+			if err != nil {
+				t.Fatalf("Len() failed with error: %v", err)
+			} else if n != len(values) {
+				t.Errorf("Len() returned incorrect results, want: %v, got: %v", len(values), n)
+			}
+
+			got := []int{}
+			for !test.heap.IsEmpty() {
+				top, err2 := test.heap.Peek()
+				if err2 != nil {
+					t.Fatalf("Peek() failed with error: %v", err2)
+				}
+
+				popped, err2 := test.heap.Pop()
+				if err2 != nil {
+					t.Fatalf("Pop() failed with error: %v", err2)
+				} else if popped != top {
+					t.Errorf("Pop() and Peek() disagree, Peek(): %v, Pop(): %v", top, popped)
+				}
+				checkHeapOrder(t, test.heap)
+				got = append(got, popped)
+			}
+
+			if test.want == nil {
+				// values that compare equal can come out in any order, so only check the keys
+				if !slices.IsSortedFunc(got, func(a, b int) int { return cmp.Compare(b%5, a%5) }) {
+					t.Errorf("Pop() returned values out of order: %v", got)
+				}
+			} else if !slices.Equal(got, test.want) {
+				t.Errorf("Pop() returned incorrect results, want: %v, got: %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestHeapify(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+	}{
+		{"nil slice", nil},
+		{"single value", []int{4}},
+		{"already sorted", []int{1, 2, 3, 4, 5, 6, 7}},
+		{"reverse sorted", []int{7, 6, 5, 4, 3, 2, 1}},
+		{"with repeats", []int{3, 1, 3, 1, 2, 2, 0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := NewMinHeap[int]()
+			err := h.Heapify(slices.Clone(test.values))
+			if err != nil {
+				t.Fatalf("Heapify() failed with error: %v", err)
+			}
+			checkHeapOrder(t, h)
+
+			want := slices.Clone(test.values)
+			slices.Sort(want)
+			if got := slices.Collect(h.Drain()); !slices.Equal(got, want) {
+				t.Errorf("Heapify() gave incorrect results, want: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestHeapFixAndUpdate(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+
+	h := NewHeap(func(a, b *task) int { return cmp.Compare(a.priority, b.priority) })
+	tasks := []*task{{"a", 5}, {"b", 3}, {"c", 8}, {"d", 1}, {"e", 6}}
+	for _, tk := range tasks {
+		err := h.Push(tk)
+		if err != nil {
+			t.Fatalf("Push() failed with error: %v", err)
+		}
+	}
+
+	// lower a priority in place, then fix the heap at that task's index
+	index := slices.Index(slices.Collect(h.All()), tasks[2])
+	tasks[2].priority = 0
+	err := h.Fix(index)
+	if err != nil {
+		t.Fatalf("Fix() failed with error: %v", err)
+	}
+	checkHeapOrder(t, h)
+
+	top, err := h.Peek()
+	if err != nil {
+		t.Fatalf("Peek() failed with error: %v", err)
+	} else if top != tasks[2] {
+		t.Errorf("Fix() gave incorrect results, want top: %v, got: %v", tasks[2].name, top.name)
+	}
+
+	// raise the priority of the top task, which should sink it
+	err = h.Update(0, &task{"c", 10})
+	if err != nil {
+		t.Fatalf("Update() failed with error: %v", err)
+	}
+	checkHeapOrder(t, h)
+
+	got := []string{}
+	for tk := range h.Drain() {
+		got = append(got, tk.name)
+	}
+
+	want := []string{"d", "b", "a", "e", "c"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Update() gave incorrect results, want: %v, got: %v", want, got)
+	}
+
+	err = h.Update(3, &task{"f", 1})
+	if err == nil {
+		t.Errorf("Update() with an index out of range should have failed")
+	} else {
+		fmt.Println(err)
+	}
+}
+
+func TestHeapRandomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(14))
+
+	h := NewMaxHeap[int]()
+	model := []int{}
+
+	for i := 0; i < 2000; i++ {
+		if len(model) == 0 || rng.Intn(3) != 0 {
+			v := rng.Intn(500)
+			if err := h.Push(v); err != nil {
+				t.Fatalf("Push() failed with error: %v", err)
+			}
+			model = append(model, v)
+			continue
+		}
+
+		got, err := h.Pop()
+		if err != nil {
+			t.Fatalf("Pop() failed with error: %v", err)
+		}
+
+		maxIndex := 0
+		for j, v := range model {
+			if v > model[maxIndex] {
+				maxIndex = j
+			}
+		}
+		if got != model[maxIndex] {
+			t.Fatalf("Pop() returned incorrect results, want: %v, got: %v", model[maxIndex], got)
+		}
+		model = slices.Delete(model, maxIndex, maxIndex+1)
+	}
+	checkHeapOrder(t, h)
+}
@@ -0,0 +1,51 @@
+package bintreelib
+
+import (
+	"iter"
+
+	"github.com/pluckynumbat/go-tree/internal/walk"
+)
+
+// All returns an iterator over the values of the binary tree, in the order they were added (breadth first order)
+func (bt *BinaryTree[T]) All() iter.Seq[T] {
+	return bt.BFS()
+}
+
+// BFS returns an iterator over the values of the binary tree using Breadth First Search
+// Breaking out of the range loop stops the traversal immediately
+func (bt *BinaryTree[T]) BFS() iter.Seq[T] {
+	return bt.values(walk.BFS[*Node[T]])
+}
+
+// PreOrder returns an iterator over the values of the binary tree using Depth First Search
+// In a pre-order manner (visit a node, then its left subtree, followed by its right subtree)
+func (bt *BinaryTree[T]) PreOrder() iter.Seq[T] {
+	return bt.values(walk.PreOrder[*Node[T]])
+}
+
+// InOrder returns an iterator over the values of the binary tree using Depth First Search
+// In an in-order manner (visit a node's left subtree, then the node itself, followed by its right subtree)
+func (bt *BinaryTree[T]) InOrder() iter.Seq[T] {
+	return bt.values(walk.InOrder[*Node[T]])
+}
+
+// PostOrder returns an iterator over the values of the binary tree using Depth First Search
+// In a post-order manner (visit a node's left subtree, then the node's right subtree, finally the node itself)
+func (bt *BinaryTree[T]) PostOrder() iter.Seq[T] {
+	return bt.values(walk.PostOrder[*Node[T]])
+}
+
+// values walks the binary tree in the given order, yielding the value of every node it visits
+func (bt *BinaryTree[T]) values(order walk.Order[*Node[T]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if bt.IsEmpty() {
+			return
+		}
+
+		for node := range order(bt.root, (*Node[T]).children) {
+			if !yield(node.data) {
+				return
+			}
+		}
+	}
+}
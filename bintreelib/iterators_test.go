@@ -0,0 +1,90 @@
+package bintreelib
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestIterators(t *testing.T) {
+	bt, err := ConstructFromValues("a", "b", "c", "d", "e", "f", "g")
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		seq  func(bt *BinaryTree[string]) iter.Seq[string]
+		want []string
+	}{
+		{"All", (*BinaryTree[string]).All, []string{"a", "b", "c", "d", "e", "f", "g"}},
+		{"BFS", (*BinaryTree[string]).BFS, []string{"a", "b", "c", "d", "e", "f", "g"}},
+		{"PreOrder", (*BinaryTree[string]).PreOrder, []string{"a", "b", "d", "e", "c", "f", "g"}},
+		{"InOrder", (*BinaryTree[string]).InOrder, []string{"d", "b", "e", "a", "f", "c", "g"}},
+		{"PostOrder", (*BinaryTree[string]).PostOrder, []string{"d", "e", "b", "f", "g", "c", "a"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var nilTree *BinaryTree[string]
+			if got := slices.Collect(test.seq(nilTree)); len(got) != 0 {
+				t.Errorf("%v() on a nil tree should not yield any values, got: %v", test.name, got)
+			}
+
+			if got := slices.Collect(test.seq(&BinaryTree[string]{})); len(got) != 0 {
+				t.Errorf("%v() on an empty tree should not yield any values, got: %v", test.name, got)
+			}
+
+			got := slices.Collect(test.seq(bt))
+			if !slices.Equal(got, test.want) {
+				t.Errorf("%v() returned incorrect results, want: %v, got: %v", test.name, test.want, got)
+			}
+
+			// breaking out of the loop should stop the walk right away
+			visited := make([]string, 0, 3)
+			for val := range test.seq(bt) {
+				visited = append(visited, val)
+				if len(visited) == 3 {
+					break
+				}
+			}
+			if !slices.Equal(visited, test.want[:3]) {
+				t.Errorf("%v() returned incorrect results after breaking early, want: %v, got: %v", test.name, test.want[:3], visited)
+			}
+		})
+	}
+
+	t.Run("iterators agree with traversal strings", func(t *testing.T) {
+		ints, err := ConstructFromValues(1, 2, 3, 4, 5, 6)
+		if err != nil {
+			t.Fatalf("ConstructFromValues() failed with error: %v", err)
+		}
+
+		traversals := []struct {
+			name     string
+			seq      iter.Seq[int]
+			traverse func() (string, error)
+		}{
+			{"BFS", ints.BFS(), ints.TraverseBFS},
+			{"PreOrder", ints.PreOrder(), ints.TraverseDFSPreOrderIterative},
+			{"InOrder", ints.InOrder(), ints.TraverseDFSInOrderIterative},
+			{"PostOrder", ints.PostOrder(), ints.TraverseDFSPostOrderIterative},
+		}
+
+		for _, traversal := range traversals {
+			want, err2 := traversal.traverse()
+			if err2 != nil {
+				t.Fatalf("traversal failed with error: %v", err2)
+			}
+
+			got := ""
+			for val := range traversal.seq {
+				got += "-" + (&Node[int]{data: val}).String() + "-"
+			}
+
+			if got != want {
+				t.Errorf("%v() returned incorrect results, want: %v, got: %v", traversal.name, want, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package bintreelib
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// jsonNode is the nested JSON form of a node: {"value":1,"left":{...},"right":{...}}, with a missing child left out
+type jsonNode[T any] struct {
+	Value *T           `json:"value"`
+	Left  *jsonNode[T] `json:"left,omitempty"`
+	Right *jsonNode[T] `json:"right,omitempty"`
+}
+
+func recurseToJSON[T comparable](node *Node[T]) *jsonNode[T] {
+	if node == nil {
+		return nil
+	}
+	return &jsonNode[T]{Value: &node.data, Left: recurseToJSON(node.left), Right: recurseToJSON(node.right)}
+}
+
+// recurseFromJSON builds the subtree described by the nested JSON node, returning an error if a node has no value
+func recurseFromJSON[T comparable](jNode *jsonNode[T], parent *Node[T]) (*Node[T], error) {
+	if jNode == nil {
+		return nil, nil
+	}
+
+	if jNode.Value == nil {
+		if parent == nil {
+			return nil, fmt.Errorf("the root node has no value")
+		}
+		return nil, fmt.Errorf("a child of the node %v has no value", parent)
+	}
+
+	node := &Node[T]{data: *jNode.Value, parent: parent}
+
+	var err error
+	node.left, err = recurseFromJSON(jNode.Left, node)
+	if err != nil {
+		return nil, err
+	}
+
+	node.right, err = recurseFromJSON(jNode.Right, node)
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// MarshalJSON is BinaryTree's implementation of the json.Marshaler interface
+// The tree is written as nested nodes, like {"value":1,"left":{"value":2},"right":{"value":3}},
+// where a missing child is left out, and an empty tree is written as null
+// encoding/json only uses it on a tree it can take the address of, so a struct holding a BinaryTree by value
+// has to be passed to json.Marshal by pointer (or hold a *BinaryTree instead)
+func (bt *BinaryTree[T]) MarshalJSON() ([]byte, error) {
+	if bt.IsNil() {
+		return nil, treeNilError
+	}
+
+	data, err := json.Marshal(recurseToJSON(bt.root))
+	if err != nil {
+		return nil, fmt.Errorf("method MarshalJSON() failed with error: %v", err)
+	}
+	return data, nil
+}
+
+// UnmarshalJSON is BinaryTree's implementation of the json.Unmarshaler interface
+// It replaces the contents of the tree with the nested nodes written by MarshalJSON, keeping their exact shape (null gives an empty tree)
+// encoding/json does not read input nested more than 10000 levels deep, so use Serialize and Deserialize for trees deeper than that
+func (bt *BinaryTree[T]) UnmarshalJSON(data []byte) error {
+	if bt.IsNil() {
+		return treeNilError
+	}
+
+	var jRoot *jsonNode[T]
+	err := json.Unmarshal(data, &jRoot)
+	if err != nil {
+		return fmt.Errorf("method UnmarshalJSON() failed with error: %v", err)
+	}
+
+	root, err := recurseFromJSON(jRoot, nil)
+	if err != nil {
+		return fmt.Errorf("method UnmarshalJSON() failed with error: %v", err)
+	}
+
+	shaped, err := newShapedTree(root)
+	if err != nil {
+		return fmt.Errorf("method UnmarshalJSON() failed with error: %v", err)
+	}
+
+	*bt = *shaped
+	return nil
+}
@@ -0,0 +1,185 @@
+package bintreelib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"empty tree", "[]", `null`},
+		{"single node", "[1]", `{"value":1}`},
+		{"complete tree", "[1,2,3,4]", `{"value":1,"left":{"value":2,"left":{"value":4}},"right":{"value":3}}`},
+		{"right chain", "[1,null,2,null,3]", `{"value":1,"right":{"value":2,"right":{"value":3}}}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bt, err := Deserialize[int](test.data)
+			if err != nil {
+				t.Fatalf("Deserialize() failed with error: %v", err)
+			}
+
+			got, err := json.Marshal(bt)
+			if err != nil {
+				t.Fatalf("json.Marshal() failed with error: %v", err)
+			}
+
+			if string(got) != test.want {
+				t.Errorf("json.Marshal() returned incorrect results, want: %v, got: %v", test.want, string(got))
+			}
+
+			// reading the JSON back gives the same tree, with its shape bookkeeping worked out again
+			readBack := &BinaryTree[int]{}
+			err = json.Unmarshal(got, readBack)
+			if err != nil {
+				t.Fatalf("json.Unmarshal() failed with error: %v", err)
+			}
+
+			equal, err := readBack.Equal(bt)
+			if err != nil {
+				t.Fatalf("Equal() failed with error: %v", err)
+			}
+			if !equal {
+				t.Errorf("json.Unmarshal() did not give back the original tree, got: %v", readBack)
+			}
+
+			wantCount, _ := bt.Count()
+			gotCount, _ := readBack.Count()
+			if gotCount != wantCount {
+				t.Errorf("Count() after json.Unmarshal() returned incorrect results, want: %v, got: %v", wantCount, gotCount)
+			}
+
+			checkNodeLinks(t, readBack)
+		})
+	}
+}
+
+func TestMarshalJSONField(t *testing.T) {
+	// a tree inside a struct, which encoding/json reaches through the pointer
+	type document struct {
+		Name string              `json:"name"`
+		Tree *BinaryTree[string] `json:"tree"`
+	}
+
+	bt, err := ConstructFromValues("a", "b", "c")
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	got, err := json.Marshal(document{"letters", bt})
+	if err != nil {
+		t.Fatalf("json.Marshal() failed with error: %v", err)
+	}
+
+	want := `{"name":"letters","tree":{"value":"a","left":{"value":"b"},"right":{"value":"c"}}}`
+	if string(got) != want {
+		t.Errorf("json.Marshal() returned incorrect results, want: %v, got: %v", want, string(got))
+	}
+
+	var doc document
+	err = json.Unmarshal(got, &doc)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() failed with error: %v", err)
+	}
+
+	traversal, err := doc.Tree.TraverseBFS()
+	if err != nil {
+		t.Fatalf("TraverseBFS() failed with error: %v", err)
+	}
+	if traversal != "-a--b--c-" {
+		t.Errorf("json.Unmarshal() returned incorrect results, want: %v, got: %v", "-a--b--c-", traversal)
+	}
+
+	// the tree is complete, so it keeps growing breadth first
+	err = doc.Tree.AddNodeBFS("d")
+	if err != nil {
+		t.Fatalf("AddNodeBFS() failed with error: %v", err)
+	}
+	leaf := doc.Tree.LastLeaf()
+	if leaf == nil || leaf.data != "d" || leaf.parent.data != "b" {
+		t.Errorf("AddNodeBFS() after json.Unmarshal() put the value in the wrong place, last leaf: %v", leaf)
+	}
+
+	// a tree held by value inside a struct, which encoding/json can only reach when the struct is passed by pointer
+	type valueDocument struct {
+		Name string             `json:"name"`
+		Tree BinaryTree[string] `json:"tree"`
+	}
+
+	got, err = json.Marshal(&valueDocument{"letters", *bt})
+	if err != nil {
+		t.Fatalf("json.Marshal() failed with error: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("json.Marshal() of a tree held by value returned incorrect results, want: %v, got: %v", want, string(got))
+	}
+
+	var valueDoc valueDocument
+	err = json.Unmarshal(got, &valueDoc)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() failed with error: %v", err)
+	}
+
+	equal, err := valueDoc.Tree.Equal(bt)
+	if err != nil {
+		t.Fatalf("Equal() failed with error: %v", err)
+	}
+	if !equal {
+		t.Errorf("json.Unmarshal() into a tree held by value returned incorrect results, got: %v", valueDoc.Tree)
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	var nilTree *BinaryTree[int]
+	err := nilTree.UnmarshalJSON([]byte(`{"value":1}`))
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("UnmarshalJSON() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	_, err = nilTree.MarshalJSON()
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("MarshalJSON() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"invalid json", `{"value":1`},
+		{"wrong value type", `{"value":"a"}`},
+		{"root without a value", `{"left":{"value":1}}`},
+		{"child without a value", `{"value":1,"right":{}}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bt, err2 := ConstructFromValues(7, 8)
+			if err2 != nil {
+				t.Fatalf("ConstructFromValues() failed with error: %v", err2)
+			}
+
+			err2 = json.Unmarshal([]byte(test.data), bt)
+			if err2 == nil {
+				t.Fatalf("json.Unmarshal() should have failed for: %v", test.data)
+			}
+			fmt.Println(err2)
+
+			// a failed read leaves the tree as it was
+			got, _ := bt.TraverseBFS()
+			if got != "-7--8-" {
+				t.Errorf("json.Unmarshal() changed the tree after failing, got: %v", got)
+			}
+		})
+	}
+}
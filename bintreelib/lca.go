@@ -0,0 +1,149 @@
+package bintreelib
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+var lcaIndexNilError = fmt.Errorf("the lowest common ancestor index is nil")
+
+// LowestCommonAncestor returns the deepest node that has both of the given nodes in its subtree (a node is its own ancestor)
+// It climbs the parent pointers, so it runs in O(h) without any preprocessing
+func (bt *BinaryTree[T]) LowestCommonAncestor(a, b *Node[T]) (*Node[T], error) {
+	if bt.IsNil() {
+		return nil, treeNilError
+	}
+
+	if a == nil || b == nil {
+		return nil, nodeNilError
+	}
+
+	depthA, depthB := bt.depthOf(a), bt.depthOf(b)
+	if depthA == invalidCount || depthB == invalidCount {
+		return nil, nodeNotInTreeError
+	}
+
+	// bring both nodes up to the same depth, then climb together till they meet
+	for ; depthA > depthB; depthA-- {
+		a = a.parent
+	}
+	for ; depthB > depthA; depthB-- {
+		b = b.parent
+	}
+	for a != b {
+		a, b = a.parent, b.parent
+	}
+
+	return a, nil
+}
+
+// depthOf returns the number of edges between the node and the root of the tree, or invalidCount if the node is not in the tree
+func (bt *BinaryTree[T]) depthOf(node *Node[T]) int {
+	if bt.IsEmpty() {
+		return invalidCount
+	}
+
+	depth := 0
+	for node.parent != nil {
+		node = node.parent
+		depth += 1
+	}
+
+	if node != bt.root {
+		return invalidCount
+	}
+	return depth
+}
+
+// LCAIndex answers lowest common ancestor queries on a snapshot of a binary tree in O(1) each, after O(n log n) preprocessing
+// It keeps the Euler tour of the tree (every node, written down each time the walk passes through it),
+// along with a sparse table of the shallowest node in every power of two sized window of the tour
+// The index does not follow later changes to the tree, so build a new one after editing it
+type LCAIndex[T comparable] struct {
+	tour   []*Node[T]
+	depths []int
+	first  map[*Node[T]]int // where each node first shows up in the tour
+	sparse [][]int          // sparse[k][i] is the tour index of the shallowest node in tour[i : i+2^k]
+}
+
+// NewLCAIndex walks the binary tree once to build an index for lowest common ancestor queries
+func (bt *BinaryTree[T]) NewLCAIndex() (*LCAIndex[T], error) {
+	if bt.IsNil() {
+		return nil, treeNilError
+	}
+
+	if bt.IsEmpty() {
+		return nil, treeEmptyError
+	}
+
+	index := &LCAIndex[T]{first: map[*Node[T]]int{}}
+	index.recurseEulerTour(bt.root, 0)
+
+	// level 0 covers windows of a single entry, and each level doubles the window using two windows of the level below
+	size := len(index.tour)
+	index.sparse = [][]int{make([]int, size)}
+	for i := range size {
+		index.sparse[0][i] = i
+	}
+
+	for k := 1; 1<<k <= size; k++ {
+		prev := index.sparse[k-1]
+		level := make([]int, size-(1<<k)+1)
+		for i := range level {
+			level[i] = index.shallower(prev[i], prev[i+(1<<(k-1))])
+		}
+		index.sparse = append(index.sparse, level)
+	}
+
+	return index, nil
+}
+
+// recurseEulerTour writes the node down in the tour before its children and again after each child returns
+func (index *LCAIndex[T]) recurseEulerTour(node *Node[T], depth int) {
+	index.first[node] = len(index.tour)
+	index.tour = append(index.tour, node)
+	index.depths = append(index.depths, depth)
+
+	for _, child := range []*Node[T]{node.left, node.right} {
+		if child != nil {
+			index.recurseEulerTour(child, depth+1)
+			index.tour = append(index.tour, node)
+			index.depths = append(index.depths, depth)
+		}
+	}
+}
+
+// shallower returns whichever of the two tour indices holds the node closer to the root
+func (index *LCAIndex[T]) shallower(i, j int) int {
+	if index.depths[j] < index.depths[i] {
+		return j
+	}
+	return i
+}
+
+// LowestCommonAncestor returns the deepest node that has both of the given nodes in its subtree, in O(1)
+// Between the first visits to the two nodes, the Euler tour passes through their common ancestor and nothing shallower
+func (index *LCAIndex[T]) LowestCommonAncestor(a, b *Node[T]) (*Node[T], error) {
+	if index == nil {
+		return nil, lcaIndexNilError
+	}
+
+	if a == nil || b == nil {
+		return nil, nodeNilError
+	}
+
+	i, presentA := index.first[a]
+	j, presentB := index.first[b]
+	if !presentA || !presentB {
+		return nil, nodeNotInTreeError
+	}
+
+	if i > j {
+		i, j = j, i
+	}
+
+	// two (possibly overlapping) windows of the largest power of two that fits cover the whole range
+	k := bits.Len(uint(j-i+1)) - 1
+	shallowest := index.shallower(index.sparse[k][i], index.sparse[k][j-(1<<k)+1])
+	return index.tour[shallowest], nil
+}
@@ -0,0 +1,212 @@
+package bintreelib
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// collectNodes returns every node of the tree, in breadth first order
+func collectNodes[T comparable](bt *BinaryTree[T]) []*Node[T] {
+	nodes := []*Node[T]{}
+	if bt.IsEmpty() {
+		return nodes
+	}
+
+	nodes = append(nodes, bt.root)
+	for i := 0; i < len(nodes); i++ {
+		for _, child := range []*Node[T]{nodes[i].left, nodes[i].right} {
+			if child != nil {
+				nodes = append(nodes, child)
+			}
+		}
+	}
+	return nodes
+}
+
+// findNode returns the first node (in breadth first order) holding the given value
+func findNode[T comparable](bt *BinaryTree[T], val T) *Node[T] {
+	for _, node := range collectNodes(bt) {
+		if node.data == val {
+			return node
+		}
+	}
+	return nil
+}
+
+func TestLowestCommonAncestor(t *testing.T) {
+	//          1
+	//        /   \
+	//       2     3
+	//      / \     \
+	//     4   5     6
+	//        / \
+	//       7   8
+	bt, err := Deserialize[int]("[1,2,3,4,5,null,6,null,null,7,8]")
+	if err != nil {
+		t.Fatalf("Deserialize() failed with error: %v", err)
+	}
+
+	index, err := bt.NewLCAIndex()
+	if err != nil {
+		t.Fatalf("NewLCAIndex() failed with error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		a, b int
+		want int
+	}{
+		{"siblings", 4, 5, 2},
+		{"cousins", 7, 4, 2},
+		{"across the root", 8, 6, 1},
+		{"ancestor and descendant", 2, 8, 2},
+		{"descendant and ancestor", 7, 5, 5},
+		{"same node", 6, 6, 6},
+		{"root and leaf", 1, 7, 1},
+		{"deep siblings", 7, 8, 5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, b := findNode(bt, test.a), findNode(bt, test.b)
+
+			got, err2 := bt.LowestCommonAncestor(a, b)
+			if err2 != nil {
+				t.Fatalf("LowestCommonAncestor() failed with error: %v", err2)
+			} else if got.data != test.want {
+				t.Errorf("LowestCommonAncestor() returned incorrect results, want: %v, got: %v", test.want, got)
+			}
+
+			got, err2 = index.LowestCommonAncestor(a, b)
+			if err2 != nil {
+				t.Fatalf("LCAIndex.LowestCommonAncestor() failed with error: %v", err2)
+			} else if got.data != test.want {
+				t.Errorf("LCAIndex.LowestCommonAncestor() returned incorrect results, want: %v, got: %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestLowestCommonAncestorErrors(t *testing.T) {
+	bt, err := ConstructFromValues(1, 2, 3)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	other, err := ConstructFromValues(4, 5)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	index, err := bt.NewLCAIndex()
+	if err != nil {
+		t.Fatalf("NewLCAIndex() failed with error: %v", err)
+	}
+
+	// RemoveValue takes the last leaf's node out of the tree, so it can't be used in a query afterwards
+	removedTree, err := ConstructFromValues(6, 7, 8, 9)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+	removed := removedTree.LastLeaf()
+	err = removedTree.RemoveValue(7)
+	if err != nil {
+		t.Fatalf("RemoveValue() failed with error: %v", err)
+	}
+
+	removedIndex, err := removedTree.NewLCAIndex()
+	if err != nil {
+		t.Fatalf("NewLCAIndex() failed with error: %v", err)
+	}
+
+	var nilTree *BinaryTree[int]
+	var nilIndex *LCAIndex[int]
+
+	tests := []struct {
+		name    string
+		query   func() (*Node[int], error)
+		wantErr error
+	}{
+		{"nil tree", func() (*Node[int], error) { return nilTree.LowestCommonAncestor(bt.Root(), bt.Root()) }, treeNilError},
+		{"nil node", func() (*Node[int], error) { return bt.LowestCommonAncestor(bt.Root(), nil) }, nodeNilError},
+		{"node of another tree", func() (*Node[int], error) { return bt.LowestCommonAncestor(bt.Root(), other.Root()) }, nodeNotInTreeError},
+		{"nil index", func() (*Node[int], error) { return nilIndex.LowestCommonAncestor(bt.Root(), bt.Root()) }, lcaIndexNilError},
+		{"nil node in the index", func() (*Node[int], error) { return index.LowestCommonAncestor(nil, bt.Root()) }, nodeNilError},
+		{"node of another tree in the index", func() (*Node[int], error) { return index.LowestCommonAncestor(other.Root(), bt.Root()) }, nodeNotInTreeError},
+		{"removed node", func() (*Node[int], error) { return removedTree.LowestCommonAncestor(removed, removedTree.Root()) }, nodeNotInTreeError},
+		{"removed node in the index", func() (*Node[int], error) { return removedIndex.LowestCommonAncestor(removed, removedTree.Root()) }, nodeNotInTreeError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err2 := test.query()
+			if !errors.Is(err2, test.wantErr) {
+				t.Errorf("the query should have failed with error: %v, got: %v", test.wantErr, err2)
+			} else {
+				fmt.Println(err2)
+			}
+		})
+	}
+
+	_, err = nilTree.NewLCAIndex()
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("NewLCAIndex() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	}
+
+	_, err = (&BinaryTree[int]{}).NewLCAIndex()
+	if !errors.Is(err, treeEmptyError) {
+		t.Errorf("NewLCAIndex() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	}
+}
+
+func TestLCAIndexRandomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(18))
+
+	for _, size := range []int{1, 2, 3, 10, 100, 500} {
+		t.Run(fmt.Sprintf("%v nodes", size), func(t *testing.T) {
+			// grow a random shape by hanging each new node off a random free slot
+			bt, err := ConstructFromValues(0)
+			if err != nil {
+				t.Fatalf("ConstructFromValues() failed with error: %v", err)
+			}
+
+			nodes := []*Node[int]{bt.Root()}
+			for len(nodes) < size {
+				parent := nodes[rng.Intn(len(nodes))]
+
+				var child *Node[int]
+				if rng.Intn(2) == 0 {
+					child, err = bt.SetLeft(parent, len(nodes))
+				} else {
+					child, err = bt.SetRight(parent, len(nodes))
+				}
+				if err == nil {
+					nodes = append(nodes, child)
+				}
+			}
+
+			index, err := bt.NewLCAIndex()
+			if err != nil {
+				t.Fatalf("NewLCAIndex() failed with error: %v", err)
+			}
+
+			for range 2000 {
+				a, b := nodes[rng.Intn(len(nodes))], nodes[rng.Intn(len(nodes))]
+
+				want, err2 := bt.LowestCommonAncestor(a, b)
+				if err2 != nil {
+					t.Fatalf("LowestCommonAncestor() failed with error: %v", err2)
+				}
+
+				got, err2 := index.LowestCommonAncestor(a, b)
+				if err2 != nil {
+					t.Fatalf("LCAIndex.LowestCommonAncestor() failed with error: %v", err2)
+				} else if got != want {
+					t.Fatalf("LCAIndex.LowestCommonAncestor(%v, %v) returned incorrect results, want: %v, got: %v", a, b, want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package bintreelib
+
+import (
+	"github.com/pluckynumbat/go-tree/internal/shape"
+)
+
+// children returns both children of the node, which is how the shape measurements walk the tree
+func (node *Node[T]) children() (*Node[T], *Node[T]) {
+	return node.left, node.right
+}
+
+// checkMeasurable returns the error for a tree that is nil or empty, since there is no shape to measure
+func (bt *BinaryTree[T]) checkMeasurable() error {
+	if bt.IsNil() {
+		return treeNilError
+	}
+
+	if bt.IsEmpty() {
+		return treeEmptyError
+	}
+	return nil
+}
+
+// measure applies one of the shape measurements to the whole binary tree, once it is sure there is a shape to measure
+func measure[T comparable, R any](bt *BinaryTree[T], measurement func(root *Node[T], children func(*Node[T]) (*Node[T], *Node[T])) R, invalid R) (R, error) {
+	err := bt.checkMeasurable()
+	if err != nil {
+		return invalid, err
+	}
+	return measurement(bt.root, (*Node[T]).children), nil
+}
+
+// Height returns the number of edges on the longest path from the root down to a leaf (a single node tree has height 0)
+func (bt *BinaryTree[T]) Height() (int, error) {
+	return measure(bt, shape.Height[*Node[T]], invalidCount)
+}
+
+// Diameter returns the number of edges on the longest path between any two nodes of the binary tree
+func (bt *BinaryTree[T]) Diameter() (int, error) {
+	return measure(bt, shape.Diameter[*Node[T]], invalidCount)
+}
+
+// LevelCounts returns the number of nodes on each level of the binary tree, starting with the root's level
+func (bt *BinaryTree[T]) LevelCounts() ([]int, error) {
+	return measure(bt, shape.LevelCounts[*Node[T]], []int(nil))
+}
+
+// MaxWidth returns the largest number of nodes on any single level of the binary tree
+func (bt *BinaryTree[T]) MaxWidth() (int, error) {
+	return measure(bt, shape.MaxWidth[*Node[T]], invalidCount)
+}
+
+// LeafCount returns the number of nodes with no children in the binary tree
+func (bt *BinaryTree[T]) LeafCount() (int, error) {
+	return measure(bt, shape.LeafCount[*Node[T]], invalidCount)
+}
+
+// IsComplete tells you if every level of the binary tree is full, except possibly the last one, which is filled from the left
+// The tree keeps track of this as it changes, so it never needs a BFS of its own
+func (bt *BinaryTree[T]) IsComplete() (bool, error) {
+	err := bt.checkMeasurable()
+	if err != nil {
+		return false, err
+	}
+	return !bt.incomplete, nil
+}
+
+// IsFull tells you if every node of the binary tree has either no children or two children
+func (bt *BinaryTree[T]) IsFull() (bool, error) {
+	return measure(bt, shape.IsFull[*Node[T]], false)
+}
+
+// IsPerfect tells you if the binary tree is full and all of its leaves are on the same level
+func (bt *BinaryTree[T]) IsPerfect() (bool, error) {
+	return measure(bt, shape.IsPerfect[*Node[T]], false)
+}
+
+// IsHeightBalanced tells you if the heights of the two subtrees of every node in the binary tree differ by at most one
+func (bt *BinaryTree[T]) IsHeightBalanced() (bool, error) {
+	return measure(bt, shape.IsHeightBalanced[*Node[T]], false)
+}
@@ -0,0 +1,165 @@
+package bintreelib
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/pluckynumbat/go-tree/internal/shape"
+)
+
+func TestMetrics(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           string
+		height         int
+		diameter       int
+		levelCounts    []int
+		maxWidth       int
+		leafCount      int
+		complete       bool
+		full           bool
+		perfect        bool
+		heightBalanced bool
+	}{
+		{"single node", "[1]", 0, 0, []int{1}, 1, 1, true, true, true, true},
+		{"perfect tree", "[1,2,3,4,5,6,7]", 2, 4, []int{1, 2, 4}, 4, 4, true, true, true, true},
+		{"complete tree", "[1,2,3,4]", 2, 3, []int{1, 2, 1}, 2, 2, true, false, false, true},
+		{"full tree", "[1,2,3,null,null,4,5]", 2, 3, []int{1, 2, 2}, 2, 3, false, true, false, true},
+		{"right chain", "[1,null,2,null,3]", 2, 2, []int{1, 1, 1}, 1, 1, false, false, false, false},
+		{"long path away from the root", "[1,2,null,3,4,5,null,null,6]", 3, 4, []int{1, 1, 2, 2}, 2, 2, false, false, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bt, err := Deserialize[int](test.data)
+			if err != nil {
+				t.Fatalf("Deserialize() failed with error: %v", err)
+			}
+
+			intResults := []struct {
+				method string
+				want   int
+				got    func() (int, error)
+			}{
+				{"Height", test.height, bt.Height},
+				{"Diameter", test.diameter, bt.Diameter},
+				{"MaxWidth", test.maxWidth, bt.MaxWidth},
+				{"LeafCount", test.leafCount, bt.LeafCount},
+			}
+
+			for _, result := range intResults {
+				got, err2 := result.got()
+				if err2 != nil {
+					t.Fatalf("%v() failed with error: %v", result.method, err2)
+				} else if got != result.want {
+					t.Errorf("%v() returned incorrect results, want: %v, got: %v", result.method, result.want, got)
+				}
+			}
+
+			boolResults := []struct {
+				method string
+				want   bool
+				got    func() (bool, error)
+			}{
+				{"IsComplete", test.complete, bt.IsComplete},
+				{"IsFull", test.full, bt.IsFull},
+				{"IsPerfect", test.perfect, bt.IsPerfect},
+				{"IsHeightBalanced", test.heightBalanced, bt.IsHeightBalanced},
+			}
+
+			for _, result := range boolResults {
+				got, err2 := result.got()
+				if err2 != nil {
+					t.Fatalf("%v() failed with error: %v", result.method, err2)
+				} else if got != result.want {
+					t.Errorf("%v() returned incorrect results, want: %v, got: %v", result.method, result.want, got)
+				}
+			}
+
+			levelCounts, err := bt.LevelCounts()
+			if err != nil {
+				t.Fatalf("LevelCounts() failed with error: %v", err)
+			} else if !slices.Equal(levelCounts, test.levelCounts) {
+				t.Errorf("LevelCounts() returned incorrect results, want: %v, got: %v", test.levelCounts, levelCounts)
+			}
+		})
+	}
+}
+
+func TestMetricsErrors(t *testing.T) {
+	var nilTree *BinaryTree[int]
+	emptyTree := &BinaryTree[int]{}
+
+	for _, bt := range []*BinaryTree[int]{nilTree, emptyTree} {
+		wantErr := treeEmptyError
+		if bt.IsNil() {
+			wantErr = treeNilError
+		}
+
+		_, err := bt.Height()
+		if !errors.Is(err, wantErr) {
+			t.Errorf("Height() should have failed with error: %v, got: %v", wantErr, err)
+		} else {
+			fmt.Println(err)
+		}
+
+		_, err = bt.IsComplete()
+		if !errors.Is(err, wantErr) {
+			t.Errorf("IsComplete() should have failed with error: %v, got: %v", wantErr, err)
+		}
+
+		_, err = bt.LevelCounts()
+		if !errors.Is(err, wantErr) {
+			t.Errorf("LevelCounts() should have failed with error: %v, got: %v", wantErr, err)
+		}
+	}
+}
+
+func TestIsCompleteTracksEdits(t *testing.T) {
+	bt, err := ConstructFromValues(1, 2, 3, 4, 5)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	// the tracked completeness should always agree with a fresh check of the shape
+	check := func(step string) {
+		t.Helper()
+
+		got, err2 := bt.IsComplete()
+		if err2 != nil {
+			t.Fatalf("IsComplete() failed with error: %v", err2)
+		}
+
+		if want := shape.IsComplete(bt.root, (*Node[int]).children); got != want {
+			t.Errorf("IsComplete() after %v returned incorrect results, want: %v, got: %v", step, want, got)
+		}
+	}
+
+	check("construction")
+
+	_, err = bt.DetachSubtree(bt.Root().left.left)
+	if err != nil {
+		t.Fatalf("DetachSubtree() failed with error: %v", err)
+	}
+	check("a detach")
+
+	err = bt.AddNodeBFS(6)
+	if err != nil {
+		t.Fatalf("AddNodeBFS() failed with error: %v", err)
+	}
+	check("an add")
+
+	_, err = bt.SetRight(bt.Root().right, 7)
+	if err != nil {
+		t.Fatalf("SetRight() failed with error: %v", err)
+	}
+	check("a set right")
+
+	err = bt.RemoveValue(1)
+	if err != nil {
+		t.Fatalf("RemoveValue() failed with error: %v", err)
+	}
+	check("a remove")
+}
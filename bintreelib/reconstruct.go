@@ -0,0 +1,218 @@
+package bintreelib
+
+import (
+	"fmt"
+
+	"github.com/pluckynumbat/go-quez/sgquezlib"
+)
+
+// ConstructFromPreAndInOrder rebuilds a binary tree from its pre-order and in-order traversals, in O(n)
+// The values have to be unique, since a repeated value makes the shape of the tree ambiguous
+func ConstructFromPreAndInOrder[T comparable](preOrder, inOrder []T) (*BinaryTree[T], error) {
+	positions, err := inOrderPositions(preOrder, inOrder, "pre-order")
+	if err != nil {
+		return nil, fmt.Errorf("construct from pre-order and in-order failed with error: %v", err)
+	}
+
+	// the pre-order is consumed from the front: a node comes before its left subtree, which comes before its right subtree
+	next := 0
+	nextNode := func() (T, int) {
+		val := preOrder[next]
+		next += 1
+		return val, next - 1
+	}
+
+	root, err := recurseBuildFromTraversal(nextNode, positions, nil, 0, len(inOrder)-1, true)
+	if err != nil {
+		return nil, fmt.Errorf("construct from pre-order and in-order failed with error: %v", err)
+	}
+
+	return newShapedTree(root)
+}
+
+// ConstructFromPostAndInOrder rebuilds a binary tree from its post-order and in-order traversals, in O(n)
+// The values have to be unique, since a repeated value makes the shape of the tree ambiguous
+func ConstructFromPostAndInOrder[T comparable](postOrder, inOrder []T) (*BinaryTree[T], error) {
+	positions, err := inOrderPositions(postOrder, inOrder, "post-order")
+	if err != nil {
+		return nil, fmt.Errorf("construct from post-order and in-order failed with error: %v", err)
+	}
+
+	// the post-order is consumed from the back: a node comes after its right subtree, which comes after its left subtree
+	next := len(postOrder) - 1
+	nextNode := func() (T, int) {
+		val := postOrder[next]
+		next -= 1
+		return val, next + 1
+	}
+
+	root, err := recurseBuildFromTraversal(nextNode, positions, nil, 0, len(inOrder)-1, false)
+	if err != nil {
+		return nil, fmt.Errorf("construct from post-order and in-order failed with error: %v", err)
+	}
+
+	return newShapedTree(root)
+}
+
+// inOrderPositions maps every value to its index in the in-order traversal,
+// after checking that the other traversal (called name in the errors) holds exactly the same unique values
+func inOrderPositions[T comparable](other, inOrder []T, name string) (map[T]int, error) {
+	if len(other) != len(inOrder) {
+		return nil, fmt.Errorf("the %v has %v values but the in-order has %v", name, len(other), len(inOrder))
+	}
+
+	positions := make(map[T]int, len(inOrder))
+	for i, val := range inOrder {
+		if _, present := positions[val]; present {
+			return nil, fmt.Errorf("the value %v appears more than once in the in-order", val)
+		}
+		positions[val] = i
+	}
+
+	seen := make(map[T]bool, len(other))
+	for _, val := range other {
+		if seen[val] {
+			return nil, fmt.Errorf("the value %v appears more than once in the %v", val, name)
+		}
+		seen[val] = true
+
+		if _, present := positions[val]; !present {
+			return nil, fmt.Errorf("the value %v is in the %v but not in the in-order", val, name)
+		}
+	}
+
+	return positions, nil
+}
+
+// recurseBuildFromTraversal builds the subtree made of the in-order values between the indices low and high (inclusive)
+// nextNode hands out the root of each subtree in turn (along with its index, for the errors),
+// and leftFirst tells whether the traversal reaches the left subtree before the right one
+func recurseBuildFromTraversal[T comparable](nextNode func() (T, int), positions map[T]int, parent *Node[T], low, high int, leftFirst bool) (*Node[T], error) {
+	if low > high {
+		return nil, nil
+	}
+
+	val, index := nextNode()
+
+	position := positions[val]
+	if position < low || position > high {
+		return nil, fmt.Errorf("the traversals do not describe the same tree, the value %v (at index %v) can't be placed under %v", val, index, parent)
+	}
+
+	node := &Node[T]{data: val, parent: parent}
+
+	var err error
+	if leftFirst {
+		node.left, err = recurseBuildFromTraversal(nextNode, positions, node, low, position-1, leftFirst)
+		if err != nil {
+			return nil, err
+		}
+		node.right, err = recurseBuildFromTraversal(nextNode, positions, node, position+1, high, leftFirst)
+	} else {
+		node.right, err = recurseBuildFromTraversal(nextNode, positions, node, position+1, high, leftFirst)
+		if err != nil {
+			return nil, err
+		}
+		node.left, err = recurseBuildFromTraversal(nextNode, positions, node, low, position-1, leftFirst)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// newShapedTree wraps a root node of any shape in a binary tree, and works out its count, last leaf and completeness
+func newShapedTree[T comparable](root *Node[T]) (*BinaryTree[T], error) {
+	bt := &BinaryTree[T]{root: root}
+
+	err := bt.refreshShape()
+	if err != nil {
+		return nil, err
+	}
+	return bt, nil
+}
+
+// refreshShape recounts the nodes of the tree with a BFS, finding the last node in breadth first order,
+// and whether the tree is complete (which it is exactly when no node turns up after the first free child slot)
+func (bt *BinaryTree[T]) refreshShape() error {
+	bt.count = 0
+	bt.lastLeaf = nil
+	bt.incomplete = false
+
+	if bt.root == nil {
+		return nil
+	}
+
+	queue := sgquezlib.SemiGenericQueue[*Node[T]]{}
+	err := queue.Enqueue(bt.root)
+	if err != nil {
+		return fmt.Errorf("refreshing the shape of the tree failed with error: %v", err)
+	}
+
+	seenGap := false
+	for !queue.IsEmpty() {
+		runner, err2 := queue.Dequeue()
+		if err2 != nil {
+			return fmt.Errorf("refreshing the shape of the tree failed with error: %v", err2)
+		}
+
+		bt.count += 1
+		bt.lastLeaf = runner
+
+		for _, child := range []*Node[T]{runner.left, runner.right} {
+			if child == nil {
+				seenGap = true
+				continue
+			}
+
+			if seenGap {
+				bt.incomplete = true
+			}
+
+			err2 = queue.Enqueue(child)
+			if err2 != nil {
+				return fmt.Errorf("refreshing the shape of the tree failed with error: %v", err2)
+			}
+		}
+	}
+
+	return nil
+}
+
+// addNodeFirstFree adds a node as the child of the first node (in breadth first order) that has a free child slot,
+// which is where AddNodeBFS would put it if the tree were complete
+func (bt *BinaryTree[T]) addNodeFirstFree(val T) error {
+	queue := sgquezlib.SemiGenericQueue[*Node[T]]{}
+	err := queue.Enqueue(bt.root)
+	if err != nil {
+		return fmt.Errorf("add node (BFS) failed with error: %v", err)
+	}
+
+	for !queue.IsEmpty() {
+		runner, err2 := queue.Dequeue()
+		if err2 != nil {
+			return fmt.Errorf("add node (BFS) failed with error: %v", err2)
+		}
+
+		if runner.left == nil {
+			runner.left = &Node[T]{data: val, parent: runner}
+			return bt.refreshShape()
+		}
+
+		if runner.right == nil {
+			runner.right = &Node[T]{data: val, parent: runner}
+			return bt.refreshShape()
+		}
+
+		err2 = queue.Enqueue(runner.left)
+		if err2 == nil {
+			err2 = queue.Enqueue(runner.right)
+		}
+		if err2 != nil {
+			return fmt.Errorf("add node (BFS) failed with error: %v", err2)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package bintreelib
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// checkNodeLinks verifies that every child in the tree points back to its parent
+func checkNodeLinks[T comparable](t *testing.T, bt *BinaryTree[T]) {
+	t.Helper()
+
+	var recurse func(node *Node[T])
+	recurse = func(node *Node[T]) {
+		for _, child := range []*Node[T]{node.left, node.right} {
+			if child == nil {
+				continue
+			}
+			if child.parent != node {
+				t.Fatalf("the node %v does not point back to its parent %v", child, node)
+			}
+			recurse(child)
+		}
+	}
+
+	if bt.root != nil {
+		if bt.root.parent != nil {
+			t.Fatalf("the root %v has a parent", bt.root)
+		}
+		recurse(bt.root)
+	}
+}
+
+func TestConstructFromTraversals(t *testing.T) {
+	tests := []struct {
+		name           string
+		bfs            []string // the breadth first order of the rebuilt tree
+		preOrder       []string
+		inOrder        []string
+		postOrder      []string
+		wantIncomplete bool
+	}{
+		{"empty tree", []string{}, []string{}, []string{}, []string{}, false},
+		{"single node", []string{"a"}, []string{"a"}, []string{"a"}, []string{"a"}, false},
+		{"complete tree",
+			[]string{"a", "b", "c", "d", "e", "f"},
+			[]string{"a", "b", "d", "e", "c", "f"},
+			[]string{"d", "b", "e", "a", "f", "c"},
+			[]string{"d", "e", "b", "f", "c", "a"},
+			false,
+		},
+		{"left chain",
+			[]string{"a", "b", "c"},
+			[]string{"a", "b", "c"},
+			[]string{"c", "b", "a"},
+			[]string{"c", "b", "a"},
+			true,
+		},
+		{"right chain",
+			[]string{"a", "b", "c"},
+			[]string{"a", "b", "c"},
+			[]string{"a", "b", "c"},
+			[]string{"c", "b", "a"},
+			true,
+		},
+		// a has children b and c, b has only a right child d, c has only a left child e
+		{"gaps in the middle",
+			[]string{"a", "b", "c", "d", "e"},
+			[]string{"a", "b", "d", "c", "e"},
+			[]string{"b", "d", "a", "e", "c"},
+			[]string{"d", "b", "e", "c", "a"},
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fromPre, err := ConstructFromPreAndInOrder(test.preOrder, test.inOrder)
+			if err != nil {
+				t.Fatalf("ConstructFromPreAndInOrder() failed with error: %v", err)
+			}
+
+			fromPost, err := ConstructFromPostAndInOrder(test.postOrder, test.inOrder)
+			if err != nil {
+				t.Fatalf("ConstructFromPostAndInOrder() failed with error: %v", err)
+			}
+
+			for _, bt := range []*BinaryTree[string]{fromPre, fromPost} {
+				checkNodeLinks(t, bt)
+
+				if got := slices.Collect(bt.BFS()); !slices.Equal(got, test.bfs) {
+					t.Errorf("BFS() returned incorrect results, want: %v, got: %v", test.bfs, got)
+				}
+				if got := slices.Collect(bt.PreOrder()); !slices.Equal(got, test.preOrder) {
+					t.Errorf("PreOrder() returned incorrect results, want: %v, got: %v", test.preOrder, got)
+				}
+				if got := slices.Collect(bt.InOrder()); !slices.Equal(got, test.inOrder) {
+					t.Errorf("InOrder() returned incorrect results, want: %v, got: %v", test.inOrder, got)
+				}
+				if got := slices.Collect(bt.PostOrder()); !slices.Equal(got, test.postOrder) {
+					t.Errorf("PostOrder() returned incorrect results, want: %v, got: %v", test.postOrder, got)
+				}
+
+				cnt, err2 := bt.Count()
+				if err2 != nil {
+					t.Fatalf("Count() failed with error: %v", err2)
+				} else if cnt != len(test.bfs) {
+					t.Errorf("Count() returned incorrect results, want: %v, got: %v", len(test.bfs), cnt)
+				}
+
+				if len(test.bfs) > 0 && bt.LastLeaf().data != test.bfs[len(test.bfs)-1] {
+					t.Errorf("LastLeaf() returned incorrect results, want: %v, got: %v", test.bfs[len(test.bfs)-1], bt.LastLeaf())
+				}
+
+				if bt.incomplete != test.wantIncomplete {
+					t.Errorf("the tree was marked incomplete: %v, want: %v", bt.incomplete, test.wantIncomplete)
+				}
+			}
+		})
+	}
+}
+
+func TestConstructFromTraversalsErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		preOrder  []int
+		postOrder []int
+		inOrder   []int
+	}{
+		{"different lengths", []int{1, 2}, []int{2, 1}, []int{1}},
+		{"repeat in the in-order", []int{1, 2}, []int{2, 1}, []int{2, 2}},
+		{"repeat in the other traversal", []int{1, 1}, []int{1, 1}, []int{1, 2}},
+		{"value missing from the in-order", []int{1, 3}, []int{3, 1}, []int{1, 2}},
+		{"traversals of different trees", []int{1, 2, 3}, []int{2, 3, 1}, []int{3, 1, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ConstructFromPreAndInOrder(test.preOrder, test.inOrder)
+			if err == nil {
+				t.Errorf("ConstructFromPreAndInOrder() should have failed for pre-order: %v, in-order: %v", test.preOrder, test.inOrder)
+			} else {
+				fmt.Println(err)
+			}
+
+			_, err = ConstructFromPostAndInOrder(test.postOrder, test.inOrder)
+			if err == nil {
+				t.Errorf("ConstructFromPostAndInOrder() should have failed for post-order: %v, in-order: %v", test.postOrder, test.inOrder)
+			} else {
+				fmt.Println(err)
+			}
+		})
+	}
+}
+
+func TestIncompleteTreeOperations(t *testing.T) {
+	// a has children b and c, b has only a right child d
+	bt, err := ConstructFromPreAndInOrder([]string{"a", "b", "d", "c"}, []string{"b", "d", "a", "c"})
+	if err != nil {
+		t.Fatalf("ConstructFromPreAndInOrder() failed with error: %v", err)
+	}
+
+	_, err = bt.ToCompact()
+	if !errors.Is(err, treeIncompleteError) {
+		t.Errorf("ToCompact() on an incomplete tree should have failed with error: %v, got: %v", treeIncompleteError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	// the free slot to the left of d's position is filled first, which makes the tree complete again
+	err = bt.AddNodeBFS("e")
+	if err != nil {
+		t.Fatalf("AddNodeBFS() failed with error: %v", err)
+	}
+	checkNodeLinks(t, bt)
+
+	want := []string{"a", "b", "c", "e", "d"}
+	if got := slices.Collect(bt.BFS()); !slices.Equal(got, want) {
+		t.Errorf("AddNodeBFS() gave incorrect results, want: %v, got: %v", want, got)
+	}
+	if bt.incomplete || bt.LastLeaf().data != "d" {
+		t.Errorf("AddNodeBFS() should have completed the tree, incomplete: %v, last leaf: %v", bt.incomplete, bt.LastLeaf())
+	}
+
+	// once complete, the tree goes back to the count based positions
+	err = bt.AddNodeBFS("f")
+	if err != nil {
+		t.Fatalf("AddNodeBFS() failed with error: %v", err)
+	}
+	if bt.LastLeaf().data != "f" || bt.LastLeaf().parent.data != "c" {
+		t.Errorf("AddNodeBFS() gave incorrect results, last leaf: %v", bt.LastLeaf())
+	}
+
+	// removing values from an incomplete tree keeps the count and last leaf up to date
+	chain, err := ConstructFromPreAndInOrder([]int{1, 2, 3, 4}, []int{4, 3, 2, 1})
+	if err != nil {
+		t.Fatalf("ConstructFromPreAndInOrder() failed with error: %v", err)
+	}
+
+	for _, test := range []struct {
+		remove int
+		want   []int
+	}{
+		{2, []int{1, 4, 3}},
+		{1, []int{3, 4}},
+		{4, []int{3}},
+		{3, []int{}},
+	} {
+		err = chain.RemoveValue(test.remove)
+		if err != nil {
+			t.Fatalf("RemoveValue() failed with error: %v", err)
+		}
+		checkNodeLinks(t, chain)
+
+		if got := slices.Collect(chain.BFS()); !slices.Equal(got, test.want) {
+			t.Errorf("RemoveValue() gave incorrect results, want: %v, got: %v", test.want, got)
+		}
+
+		cnt, err2 := chain.Count()
+		if err2 != nil {
+			t.Fatalf("Count() failed with error: %v", err2)
+		} else if cnt != len(test.want) {
+			t.Errorf("Count() returned incorrect results, want: %v, got: %v", len(test.want), cnt)
+		}
+	}
+}
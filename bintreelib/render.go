@@ -0,0 +1,38 @@
+package bintreelib
+
+import (
+	"fmt"
+
+	"github.com/pluckynumbat/go-tree/internal/render"
+)
+
+// RenderOptions controls how Render draws a tree: its layout, the character set of the connectors, and the maximum line width
+// The zero value draws the tree top-down with Unicode box drawing characters, with no width limit
+type RenderOptions = render.Options
+
+// RenderLayout picks between the layouts Render can draw
+type RenderLayout = render.Layout
+
+const (
+	TopDown  RenderLayout = render.TopDown  // the root on the first line, with connector lines down to each level below it
+	Sideways RenderLayout = render.Sideways // one node per line, indented under its parent, which stays readable for wide trees
+)
+
+// Render draws the shape of the binary tree as text, one line per row, with a trailing newline
+// In the sideways layout, the left child comes first, and a missing child is shown as nil when its sibling is present
+// The top-down layout returns an error if the tree is wider than the maximum width, while the sideways one cuts long lines short
+func (bt *BinaryTree[T]) Render(opts RenderOptions) (string, error) {
+	if bt.IsNil() {
+		return "", treeNilError
+	}
+
+	if bt.IsEmpty() {
+		return "", treeEmptyError
+	}
+
+	drawing, err := render.Draw(bt.root, (*Node[T]).children, (*Node[T]).String, opts)
+	if err != nil {
+		return "", fmt.Errorf("render failed with error: %v", err)
+	}
+	return drawing, nil
+}
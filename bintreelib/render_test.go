@@ -0,0 +1,103 @@
+package bintreelib
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		opts RenderOptions
+		want []string
+	}{
+		{"single node", "[1]", RenderOptions{}, []string{"1"}},
+		{"top down complete", "[1,2,3,4,5]", RenderOptions{}, []string{
+			"  1",
+			" ┌┴─┐",
+			" 2  3",
+			"┌┴┐",
+			"4 5",
+		}},
+		{"top down ascii", "[1,2,3]", RenderOptions{ASCII: true}, []string{
+			" 1",
+			"+++",
+			"2 3",
+		}},
+		{"top down incomplete", "[1,null,2,3]", RenderOptions{}, []string{
+			"1",
+			"└┐",
+			" 2",
+			"┌┘",
+			"3",
+		}},
+		{"sideways", "[1,2,3,null,4]", RenderOptions{Layout: Sideways}, []string{
+			"1",
+			"├── 2",
+			"│   ├── nil",
+			"│   └── 4",
+			"└── 3",
+		}},
+		{"sideways ascii with max width", "[1,2,3,null,4]", RenderOptions{Layout: Sideways, ASCII: true, MaxWidth: 8}, []string{
+			"1",
+			"|-- 2",
+			"|   |...",
+			"|   `...",
+			"`-- 3",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bt, err := Deserialize[int](test.data)
+			if err != nil {
+				t.Fatalf("Deserialize() failed with error: %v", err)
+			}
+
+			got, err := bt.Render(test.opts)
+			if err != nil {
+				t.Fatalf("Render() failed with error: %v", err)
+			}
+
+			want := strings.Join(test.want, "\n") + "\n"
+			if got != want {
+				t.Errorf("Render() returned incorrect results, want:\n%v\ngot:\n%v", want, got)
+			}
+		})
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	var nilTree *BinaryTree[int]
+
+	_, err := nilTree.Render(RenderOptions{})
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("Render() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	_, err = (&BinaryTree[int]{}).Render(RenderOptions{})
+	if !errors.Is(err, treeEmptyError) {
+		t.Errorf("Render() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	bt, err := ConstructFromValues(1, 2, 3, 4, 5, 6, 7)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	for _, opts := range []RenderOptions{{MaxWidth: 6}, {MaxWidth: -1}, {Layout: RenderLayout(7)}} {
+		_, err = bt.Render(opts)
+		if err == nil {
+			t.Errorf("Render() with options %+v should have failed", opts)
+		} else {
+			fmt.Println(err)
+		}
+	}
+}
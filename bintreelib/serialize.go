@@ -0,0 +1,116 @@
+package bintreelib
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pluckynumbat/go-quez/sgquezlib"
+)
+
+// Serialize writes the binary tree in the level order format with null markers, for example [1,2,null,3]
+// Every node is followed (level by level) by its two children, with null for a missing child, and trailing nulls are dropped
+// The values are written as JSON, so strings come out quoted: ["a","b",null,"c"]
+func (bt *BinaryTree[T]) Serialize() (string, error) {
+	if bt.IsNil() {
+		return "", treeNilError
+	}
+
+	values := []*T{}
+
+	if !bt.IsEmpty() {
+		queue := sgquezlib.SemiGenericQueue[*Node[T]]{}
+		err := queue.Enqueue(bt.root)
+		if err != nil {
+			return "", fmt.Errorf("method Serialize() failed with error: %v", err)
+		}
+
+		for !queue.IsEmpty() {
+			runner, err2 := queue.Dequeue()
+			if err2 != nil {
+				return "", fmt.Errorf("method Serialize() failed with error: %v", err2)
+			}
+
+			if runner == nil {
+				values = append(values, nil)
+				continue
+			}
+			values = append(values, &runner.data)
+
+			err2 = queue.Enqueue(runner.left)
+			if err2 == nil {
+				err2 = queue.Enqueue(runner.right)
+			}
+			if err2 != nil {
+				return "", fmt.Errorf("method Serialize() failed with error: %v", err2)
+			}
+		}
+
+		// the last value is always a node, so everything after it is a null marker
+		for values[len(values)-1] == nil {
+			values = values[:len(values)-1]
+		}
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("method Serialize() failed with error: %v", err)
+	}
+	return string(data), nil
+}
+
+// Deserialize builds a binary tree of any shape out of the level order format with null markers, for example [1,2,null,3]
+// Both [] and [null] give an empty tree. The resulting tree has the exact shape described,
+// with its last leaf set to the last node in breadth first order
+func Deserialize[T comparable](data string) (*BinaryTree[T], error) {
+	var values []*T
+	err := json.Unmarshal([]byte(data), &values)
+	if err != nil {
+		return nil, fmt.Errorf("deserialize failed with error: %v", err)
+	}
+
+	if len(values) == 0 || (len(values) == 1 && values[0] == nil) {
+		return &BinaryTree[T]{}, nil
+	}
+
+	if values[0] == nil {
+		return nil, fmt.Errorf("deserialize failed with error: the root is null, but more values follow it")
+	}
+
+	root := &Node[T]{data: *values[0]}
+
+	queue := sgquezlib.SemiGenericQueue[*Node[T]]{}
+	err = queue.Enqueue(root)
+	if err != nil {
+		return nil, fmt.Errorf("deserialize failed with error: %v", err)
+	}
+
+	// each node in the queue takes the next two values as its children
+	next := 1
+	for next < len(values) {
+		if queue.IsEmpty() {
+			return nil, fmt.Errorf("deserialize failed with error: the value at index %v has no parent node", next)
+		}
+
+		parent, err2 := queue.Dequeue()
+		if err2 != nil {
+			return nil, fmt.Errorf("deserialize failed with error: %v", err2)
+		}
+
+		for _, child := range []**Node[T]{&parent.left, &parent.right} {
+			if next >= len(values) {
+				break
+			}
+
+			if values[next] != nil {
+				*child = &Node[T]{data: *values[next], parent: parent}
+				err2 = queue.Enqueue(*child)
+				if err2 != nil {
+					return nil, fmt.Errorf("deserialize failed with error: %v", err2)
+				}
+			}
+			next += 1
+		}
+	}
+
+	return newShapedTree(root)
+}
@@ -0,0 +1,192 @@
+package bintreelib
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestSerialize(t *testing.T) {
+	var nilTree *BinaryTree[int]
+	_, err := nilTree.Serialize()
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("Serialize() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	complete, err := ConstructFromValues(1, 2, 3, 4, 5, 6)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	// 1 has children 2 and 3, 2 has only a right child 4, 3 has only a left child 5
+	gaps, err := ConstructFromPreAndInOrder([]int{1, 2, 4, 3, 5}, []int{2, 4, 1, 5, 3})
+	if err != nil {
+		t.Fatalf("ConstructFromPreAndInOrder() failed with error: %v", err)
+	}
+
+	// 1 -> 2 -> 3 down the right side
+	rightChain, err := ConstructFromPreAndInOrder([]int{1, 2, 3}, []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("ConstructFromPreAndInOrder() failed with error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		bt   *BinaryTree[int]
+		want string
+	}{
+		{"empty tree", &BinaryTree[int]{}, "[]"},
+		{"complete tree", complete, "[1,2,3,4,5,6]"},
+		{"gaps in the middle", gaps, "[1,2,3,null,4,5]"},
+		{"right chain", rightChain, "[1,null,2,null,3]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err2 := test.bt.Serialize()
+			if err2 != nil {
+				t.Fatalf("Serialize() failed with error: %v", err2)
+			} else if got != test.want {
+				t.Errorf("Serialize() returned incorrect results, want: %v, got: %v", test.want, got)
+			}
+		})
+	}
+
+	strTree, err := ConstructFromValues("a", "b")
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	got, err := strTree.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize() failed with error: %v", err)
+	} else if got != `["a","b"]` {
+		t.Errorf("Serialize() returned incorrect results, want: %v, got: %v", `["a","b"]`, got)
+	}
+}
+
+func TestDeserialize(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           string
+		wantPreOrder   []int
+		wantInOrder    []int
+		wantLastLeaf   int
+		wantIncomplete bool
+	}{
+		{"single node", "[1]", []int{1}, []int{1}, 1, false},
+		{"complete tree", "[1,2,3,4,5]", []int{1, 2, 4, 5, 3}, []int{4, 2, 5, 1, 3}, 5, false},
+		{"bug report tree", "[1,2,null,3]", []int{1, 2, 3}, []int{3, 2, 1}, 3, true},
+		{"gaps in the middle", "[1,2,3,null,4,5]", []int{1, 2, 4, 3, 5}, []int{2, 4, 1, 5, 3}, 5, true},
+		{"trailing nulls", "[1,2,3,null,null,null,null]", []int{1, 2, 3}, []int{2, 1, 3}, 3, false},
+		{"right chain", "[1,null,2,null,3]", []int{1, 2, 3}, []int{1, 2, 3}, 3, true},
+		{"whitespace", " [ 1 , null , 2 ] ", []int{1, 2}, []int{1, 2}, 2, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bt, err := Deserialize[int](test.data)
+			if err != nil {
+				t.Fatalf("Deserialize() failed with error: %v", err)
+			}
+			checkNodeLinks(t, bt)
+
+			if got := slices.Collect(bt.PreOrder()); !slices.Equal(got, test.wantPreOrder) {
+				t.Errorf("Deserialize() gave an incorrect pre-order, want: %v, got: %v", test.wantPreOrder, got)
+			}
+			if got := slices.Collect(bt.InOrder()); !slices.Equal(got, test.wantInOrder) {
+				t.Errorf("Deserialize() gave an incorrect in-order, want: %v, got: %v", test.wantInOrder, got)
+			}
+
+			if bt.LastLeaf().data != test.wantLastLeaf {
+				t.Errorf("LastLeaf() returned incorrect results, want: %v, got: %v", test.wantLastLeaf, bt.LastLeaf())
+			}
+
+			cnt, err := bt.Count()
+			if err != nil {
+				t.Fatalf("Count() failed with error: %v", err)
+			} else if cnt != len(test.wantPreOrder) {
+				t.Errorf("Count() returned incorrect results, want: %v, got: %v", len(test.wantPreOrder), cnt)
+			}
+
+			if bt.incomplete != test.wantIncomplete {
+				t.Errorf("the tree was marked incomplete: %v, want: %v", bt.incomplete, test.wantIncomplete)
+			}
+		})
+	}
+
+	for _, data := range []string{"[]", "[null]"} {
+		bt, err := Deserialize[int](data)
+		if err != nil {
+			t.Fatalf("Deserialize() failed with error: %v", err)
+		} else if !bt.IsEmpty() {
+			t.Errorf("Deserialize(%v) should give an empty tree", data)
+		}
+	}
+}
+
+func TestDeserializeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not json", "1,2,3"},
+		{"not an array", "{}"},
+		{"wrong value type", `[1,"a"]`},
+		{"null root with children", "[null,1]"},
+		{"values without a parent", "[1,null,null,2]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Deserialize[int](test.data)
+			if err == nil {
+				t.Errorf("Deserialize() should have failed for: %v", test.data)
+			} else {
+				fmt.Println(err)
+			}
+		})
+	}
+}
+
+func TestSerializeRoundTrip(t *testing.T) {
+	for _, data := range []string{
+		"[]",
+		"[1]",
+		"[1,2,3,4,5,6,7]",
+		"[1,2,null,3]",
+		"[5,4,8,11,null,13,4,7,2,null,null,null,1]",
+		"[1,null,2,null,3,null,4]",
+	} {
+		t.Run(data, func(t *testing.T) {
+			bt, err := Deserialize[int](data)
+			if err != nil {
+				t.Fatalf("Deserialize() failed with error: %v", err)
+			}
+
+			got, err := bt.Serialize()
+			if err != nil {
+				t.Fatalf("Serialize() failed with error: %v", err)
+			} else if got != data {
+				t.Errorf("round trip changed the tree, want: %v, got: %v", data, got)
+			}
+
+			// a deserialized tree should keep working like any other binary tree
+			err = bt.AddNodeBFS(100)
+			if err != nil {
+				t.Fatalf("AddNodeBFS() failed with error: %v", err)
+			}
+			checkNodeLinks(t, bt)
+
+			contains, err := bt.Contains(100)
+			if err != nil {
+				t.Fatalf("Contains() failed with error: %v", err)
+			} else if !contains {
+				t.Errorf("AddNodeBFS() after Deserialize() lost the new value")
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package bintreelib
+
+// Clone returns a deep copy of the binary tree, which shares no nodes with the original,
+// so changing one of the two trees never affects the other
+func (bt *BinaryTree[T]) Clone() (*BinaryTree[T], error) {
+	return MapTree(bt, func(val T) T { return val })
+}
+
+// MapTree builds a binary tree with the same shape as the given one, holding the results of f on its values
+// The new tree can hold values of a different type, and keeps the count, last leaf and completeness of the original
+func MapTree[T, U comparable](bt *BinaryTree[T], f func(T) U) (*BinaryTree[U], error) {
+	if bt.IsNil() {
+		return nil, treeNilError
+	}
+
+	mapped := &BinaryTree[U]{count: bt.count, incomplete: bt.incomplete}
+	mapped.root = recurseMapNode(bt.root, nil, f, bt.lastLeaf, &mapped.lastLeaf)
+	return mapped, nil
+}
+
+// recurseMapNode copies the subtree rooted at the node, applying f to every value,
+// and points lastLeaf at the copy of the original last leaf when it comes across it
+func recurseMapNode[T, U comparable](node *Node[T], parent *Node[U], f func(T) U, originalLastLeaf *Node[T], lastLeaf **Node[U]) *Node[U] {
+	if node == nil {
+		return nil
+	}
+
+	copied := &Node[U]{data: f(node.data), parent: parent}
+	if node == originalLastLeaf {
+		*lastLeaf = copied
+	}
+
+	copied.left = recurseMapNode(node.left, copied, f, originalLastLeaf, lastLeaf)
+	copied.right = recurseMapNode(node.right, copied, f, originalLastLeaf, lastLeaf)
+	return copied
+}
+
+// Mirror returns a copy of the binary tree reflected left to right, leaving the original as it is
+func (bt *BinaryTree[T]) Mirror() (*BinaryTree[T], error) {
+	mirrored, err := bt.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	err = mirrored.Invert()
+	if err != nil {
+		return nil, err
+	}
+	return mirrored, nil
+}
+
+// Invert reflects the binary tree left to right in place, by swapping the children of every node
+// Unless the tree is perfect, it is no longer complete afterwards
+func (bt *BinaryTree[T]) Invert() error {
+	if bt.IsNil() {
+		return treeNilError
+	}
+
+	recurseInvert(bt.root)
+	return bt.refreshShape()
+}
+
+func recurseInvert[T comparable](node *Node[T]) {
+	if node == nil {
+		return
+	}
+
+	node.left, node.right = node.right, node.left
+	recurseInvert(node.left)
+	recurseInvert(node.right)
+}
@@ -0,0 +1,159 @@
+package bintreelib
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	var nilTree *BinaryTree[int]
+	_, err := nilTree.Clone()
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("Clone() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	for _, data := range []string{"[]", "[1]", "[1,2,3,4,5]", "[1,2,null,3]", "[1,null,2,3]"} {
+		t.Run(data, func(t *testing.T) {
+			original, err2 := Deserialize[int](data)
+			if err2 != nil {
+				t.Fatalf("Deserialize() failed with error: %v", err2)
+			}
+
+			clone, err2 := original.Clone()
+			if err2 != nil {
+				t.Fatalf("Clone() failed with error: %v", err2)
+			}
+			checkNodeLinks(t, clone)
+
+			equal, err2 := clone.Equal(original)
+			if err2 != nil {
+				t.Fatalf("Equal() failed with error: %v", err2)
+			} else if !equal {
+				t.Errorf("Clone() gave a different tree")
+			}
+
+			if clone.count != original.count || clone.incomplete != original.incomplete {
+				t.Errorf("Clone() did not keep the shape bookkeeping, count: %v, incomplete: %v", clone.count, clone.incomplete)
+			}
+
+			if original.IsEmpty() {
+				return
+			}
+
+			if clone.LastLeaf() == original.LastLeaf() || clone.LastLeaf().data != original.LastLeaf().data {
+				t.Errorf("Clone() did not point the last leaf at the copied node, got: %v", clone.LastLeaf())
+			}
+
+			// no node is shared, so changes to the clone leave the original alone
+			clone.Root().data = 100
+			err2 = clone.AddNodeBFS(200)
+			if err2 != nil {
+				t.Fatalf("AddNodeBFS() failed with error: %v", err2)
+			}
+
+			if got, _ := original.Serialize(); got != data {
+				t.Errorf("the original tree changed with its clone, want: %v, got: %v", data, got)
+			}
+		})
+	}
+}
+
+func TestMirrorAndInvert(t *testing.T) {
+	original, err := Deserialize[int]("[1,2,3,4,5,6]")
+	if err != nil {
+		t.Fatalf("Deserialize() failed with error: %v", err)
+	}
+
+	mirrored, err := original.Mirror()
+	if err != nil {
+		t.Fatalf("Mirror() failed with error: %v", err)
+	}
+	checkNodeLinks(t, mirrored)
+
+	want := "[1,3,2,null,6,5,4]"
+	if got, _ := mirrored.Serialize(); got != want {
+		t.Errorf("Mirror() gave incorrect results, want: %v, got: %v", want, got)
+	}
+
+	if got, _ := original.Serialize(); got != "[1,2,3,4,5,6]" {
+		t.Errorf("Mirror() changed the original tree, got: %v", got)
+	}
+
+	isMirror, err := mirrored.IsMirrorOf(original)
+	if err != nil {
+		t.Fatalf("IsMirrorOf() failed with error: %v", err)
+	} else if !isMirror {
+		t.Errorf("Mirror() gave a tree that is not the mirror of the original")
+	}
+
+	// the mirrored tree has a gap on its third level now, and its last node in breadth first order is 4
+	complete, err := mirrored.IsComplete()
+	if err != nil {
+		t.Fatalf("IsComplete() failed with error: %v", err)
+	} else if complete || mirrored.LastLeaf().data != 4 {
+		t.Errorf("Mirror() did not update the shape, complete: %v, last leaf: %v", complete, mirrored.LastLeaf())
+	}
+
+	// inverting twice gives back the original tree
+	for range 2 {
+		err = original.Invert()
+		if err != nil {
+			t.Fatalf("Invert() failed with error: %v", err)
+		}
+	}
+	checkNodeLinks(t, original)
+
+	if got, _ := original.Serialize(); got != "[1,2,3,4,5,6]" {
+		t.Errorf("inverting twice should give back the original tree, got: %v", got)
+	}
+
+	complete, err = original.IsComplete()
+	if err != nil {
+		t.Fatalf("IsComplete() failed with error: %v", err)
+	} else if !complete || original.LastLeaf().data != 6 {
+		t.Errorf("Invert() did not update the shape, complete: %v, last leaf: %v", complete, original.LastLeaf())
+	}
+
+	var nilTree *BinaryTree[int]
+	err = nilTree.Invert()
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("Invert() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	}
+}
+
+func TestMapTree(t *testing.T) {
+	bt, err := Deserialize[int]("[1,2,3,null,4]")
+	if err != nil {
+		t.Fatalf("Deserialize() failed with error: %v", err)
+	}
+
+	labels, err := MapTree(bt, func(val int) string { return strings.Repeat("*", val) })
+	if err != nil {
+		t.Fatalf("MapTree() failed with error: %v", err)
+	}
+	checkNodeLinks(t, labels)
+
+	want := `["*","**","***",null,"****"]`
+	if got, _ := labels.Serialize(); got != want {
+		t.Errorf("MapTree() gave incorrect results, want: %v, got: %v", want, got)
+	}
+
+	if labels.LastLeaf().data != "****" || !labels.incomplete {
+		t.Errorf("MapTree() did not keep the shape, last leaf: %v, incomplete: %v", labels.LastLeaf(), labels.incomplete)
+	}
+
+	wantInOrder := []string{"**", "****", "*", "***"}
+	if got := slices.Collect(labels.InOrder()); !slices.Equal(got, wantInOrder) {
+		t.Errorf("MapTree() gave incorrect results, want: %v, got: %v", wantInOrder, got)
+	}
+
+	_, err = MapTree[int, int](nil, func(val int) int { return val })
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("MapTree() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	}
+}
@@ -0,0 +1,99 @@
+package bstreelib
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// NewAVL returns an empty self balancing (AVL) binary search tree that orders its elements using cmp.Compare
+// The tree rotates nodes on every insert and delete, so its height always stays within about 1.44 log n
+func NewAVL[T cmp.Ordered]() *BinarySearchTree[T] {
+	return &BinarySearchTree[T]{compare: cmp.Compare[T], selfBalancing: true}
+}
+
+// NewAVLWithComparator returns an empty self balancing (AVL) binary search tree that orders its elements using the given comparator
+func NewAVLWithComparator[T any](compare func(a, b T) int) *BinarySearchTree[T] {
+	return &BinarySearchTree[T]{compare: compare, selfBalancing: true}
+}
+
+// ConstructAVLFromValues is a helper function to insert all the given values into a self balancing (AVL) binary search tree
+func ConstructAVLFromValues[T cmp.Ordered](values ...T) (*BinarySearchTree[T], error) {
+	bstree := NewAVL[T]()
+
+	for _, val := range values {
+		err := bstree.Insert(val)
+		if err != nil {
+			return nil, fmt.Errorf("construct AVL from values failed with error: %v", err)
+		}
+	}
+
+	return bstree, nil
+}
+
+// IsSelfBalancing tells you if the binary search tree rebalances itself on every insert and delete
+func (bst *BinarySearchTree[T]) IsSelfBalancing() bool {
+	return !bst.IsNil() && bst.selfBalancing
+}
+
+// balanceFactor is the difference between the heights of the left and right subtrees of the node
+func (node *Node[T]) balanceFactor() int {
+	return node.left.subtreeHeight() - node.right.subtreeHeight()
+}
+
+// rebalance rotates the subtree rooted at the node if its two sides differ in height by more than 1,
+// and returns the (possibly new) root of the subtree. The node's size and height must already be up-to-date
+func (bst *BinarySearchTree[T]) rebalance(node *Node[T]) *Node[T] {
+	balance := node.balanceFactor()
+
+	if balance > 1 { // left heavy
+		if node.left.balanceFactor() < 0 { // left-right case
+			bst.rotateLeft(node.left)
+		}
+		return bst.rotateRight(node)
+	}
+
+	if balance < -1 { // right heavy
+		if node.right.balanceFactor() > 0 { // right-left case
+			bst.rotateRight(node.right)
+		}
+		return bst.rotateLeft(node)
+	}
+
+	return node
+}
+
+// rotateLeft makes the right child of the node the new root of its subtree, and returns it
+func (bst *BinarySearchTree[T]) rotateLeft(node *Node[T]) *Node[T] {
+	pivot := node.right
+
+	node.right = pivot.left
+	if pivot.left != nil {
+		pivot.left.parent = node
+	}
+
+	bst.transplant(node, pivot)
+	pivot.left = node
+	node.parent = pivot
+
+	node.update()
+	pivot.update()
+	return pivot
+}
+
+// rotateRight makes the left child of the node the new root of its subtree, and returns it
+func (bst *BinarySearchTree[T]) rotateRight(node *Node[T]) *Node[T] {
+	pivot := node.left
+
+	node.left = pivot.right
+	if pivot.right != nil {
+		pivot.right.parent = node
+	}
+
+	bst.transplant(node, pivot)
+	pivot.right = node
+	node.parent = pivot
+
+	node.update()
+	pivot.update()
+	return pivot
+}
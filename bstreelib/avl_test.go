@@ -0,0 +1,176 @@
+package bstreelib
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// checkAVLInvariants walks the whole tree and reports any node with an incorrect height, or which is out of balance
+func checkAVLInvariants[T any](t *testing.T, bst *BinarySearchTree[T]) {
+	t.Helper()
+
+	var recurse func(node *Node[T]) int
+	recurse = func(node *Node[T]) int {
+		if node == nil {
+			return 0
+		}
+
+		left, right := recurse(node.left), recurse(node.right)
+		if want := 1 + max(left, right); node.height != want {
+			t.Errorf("incorrect height for node %v, want: %v, got: %v", node, want, node.height)
+		}
+		if left-right > 1 || right-left > 1 {
+			t.Errorf("node %v is out of balance, left height: %v, right height: %v", node, left, right)
+		}
+		return 1 + max(left, right)
+	}
+	recurse(bst.root)
+
+	checkParentPointers(t, bst)
+	checkSubtreeSizes(t, bst)
+}
+
+func TestIsSelfBalancing(t *testing.T) {
+	var bst *BinarySearchTree[prInt]
+
+	tests := []struct {
+		name string
+		bst  *BinarySearchTree[prInt]
+		want bool
+	}{
+		{"nil tree", bst, false},
+		{"zero value tree", &BinarySearchTree[prInt]{}, false},
+		{"plain tree", New[prInt](), false},
+		{"AVL tree", NewAVL[prInt](), true},
+		{"AVL tree with comparator", NewAVLWithComparator(cmp.Compare[prInt]), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.bst.IsSelfBalancing()
+			if got != test.want {
+				t.Errorf("IsSelfBalancing() returned incorrect results, want: %v, got: %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestAVLInsert(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []prInt
+		wantBFS string
+	}{
+		{"left-left case", []prInt{3, 2, 1}, "-(2)--(1)--(3)-"},
+		{"right-right case", []prInt{1, 2, 3}, "-(2)--(1)--(3)-"},
+		{"left-right case", []prInt{3, 1, 2}, "-(2)--(1)--(3)-"},
+		{"right-left case", []prInt{1, 3, 2}, "-(2)--(1)--(3)-"},
+		{"7 sorted elements", []prInt{1, 2, 3, 4, 5, 6, 7}, "-(4)--(2)--(6)--(1)--(3)--(5)--(7)-"},
+		{"7 reverse sorted elements", []prInt{7, 6, 5, 4, 3, 2, 1}, "-(4)--(2)--(6)--(1)--(3)--(5)--(7)-"},
+		{"double rotation with subtrees", []prInt{5, 2, 8, 1, 4, 3}, "-(4)--(2)--(5)--(1)--(3)--(8)-"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bst, err := ConstructAVLFromValues(test.input...)
+			if err != nil {
+				t.Fatalf("ConstructAVLFromValues() failed with error: %v", err)
+			}
+
+			got, err := bst.TraverseBFS()
+			if err != nil {
+				t.Fatalf("TraverseBFS() failed with error: %v", err)
+			} else if got != test.wantBFS {
+				t.Errorf("AVL Insert() gave incorrect results, want: %v, got: %v", test.wantBFS, got)
+			}
+
+			checkAVLInvariants(t, bst)
+		})
+	}
+
+	t.Run("duplicate value", func(t *testing.T) {
+		_, err := ConstructAVLFromValues[prInt](1, 2, 1)
+		if err == nil {
+			t.Fatalf("ConstructAVLFromValues() with a duplicate value should have failed")
+		}
+	})
+}
+
+func TestAVLHeightOnSortedInput(t *testing.T) {
+	for _, n := range []int{10, 100, 1000, 5000} {
+		bst := NewAVL[int]()
+		for i := range n {
+			err := bst.Insert(i)
+			if err != nil {
+				t.Fatalf("Insert() failed with error: %v", err)
+			}
+		}
+
+		limit := int(1.44 * math.Log2(float64(n+2)))
+		if bst.root.height > limit {
+			t.Errorf("AVL tree of %v sorted values is too tall, want at most: %v, got: %v", n, limit, bst.root.height)
+		}
+
+		found, err := bst.Search(n / 2)
+		if err != nil {
+			t.Fatalf("Search() failed with error: %v", err)
+		} else if !found {
+			t.Errorf("Search() could not find the value %v", n/2)
+		}
+	}
+}
+
+func TestAVLDelete(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 5))
+	bst := NewAVL[int]()
+	present := make([]int, 0, 300)
+
+	for step := 0; step < 3000; step++ {
+		val := rng.IntN(300)
+		idx, found := slices.BinarySearch(present, val)
+
+		if found {
+			err := bst.Delete(val)
+			if err != nil {
+				t.Fatalf("Delete() failed with error: %v", err)
+			}
+			present = slices.Delete(present, idx, idx+1)
+		} else {
+			err := bst.Insert(val)
+			if err != nil {
+				t.Fatalf("Insert() failed with error: %v", err)
+			}
+			present = slices.Insert(present, idx, val)
+		}
+
+		if step%500 == 0 {
+			checkAVLInvariants(t, bst)
+		}
+	}
+
+	checkAVLInvariants(t, bst)
+
+	got, err := bst.ConstructOrderedSlice()
+	if err != nil {
+		t.Fatalf("ConstructOrderedSlice() failed with error: %v", err)
+	} else if !slices.Equal(got, present) {
+		t.Errorf("ConstructOrderedSlice() returned incorrect results, want: %v, got: %v", present, got)
+	}
+
+	// delete everything, in an order that keeps hitting the root
+	for len(present) > 0 {
+		err = bst.Delete(bst.root.data)
+		if err != nil {
+			t.Fatalf("Delete() failed with error: %v", err)
+		}
+		present = present[1:]
+		checkAVLInvariants(t, bst)
+	}
+
+	if !bst.IsEmpty() {
+		t.Errorf("the AVL tree should be empty after deleting every value")
+	}
+}
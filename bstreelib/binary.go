@@ -0,0 +1,93 @@
+package bstreelib
+
+import (
+	"fmt"
+
+	"github.com/pluckynumbat/go-tree/internal/binfmt"
+)
+
+var codecNilError = fmt.Errorf("the value codec is nil")
+
+// ValueCodec turns the values of a tree into bytes and back, for the binary encoding
+// It is the shared binfmt codec under the package's own name, so both tree packages accept the same codecs
+type ValueCodec[T any] = binfmt.Codec[T]
+
+// MarshalBinary is BinarySearchTree's implementation of the encoding.BinaryMarshaler interface, which also makes encoding/gob use it
+// The compact format has a magic and version header, a varint shape bitmap, length prefixed values, and a CRC-32 checksum
+// A tree with the CountDuplicates policy also writes the number of times each value occurs
+// Booleans, integers, floats and strings are written directly, types with their own MarshalBinary and UnmarshalBinary use those,
+// and any other values are written as JSON. MarshalBinaryWithCodec takes a codec for the values instead
+// The comparator, balancing mode and duplicate policy are not written, they belong to the tree the data gets read into
+// encoding/gob only uses it on a tree it can take the address of, so a struct holding a BinarySearchTree by value
+// has to be passed to gob by pointer (or hold a *BinarySearchTree instead)
+func (bst *BinarySearchTree[T]) MarshalBinary() ([]byte, error) {
+	return bst.MarshalBinaryWithCodec(binfmt.DefaultCodec[T]())
+}
+
+// MarshalBinaryWithCodec writes the binary search tree in the same compact format as MarshalBinary, using the given codec for the values
+func (bst *BinarySearchTree[T]) MarshalBinaryWithCodec(codec ValueCodec[T]) ([]byte, error) {
+	if bst.IsNil() {
+		return nil, treeNilError
+	}
+
+	if codec == nil {
+		return nil, codecNilError
+	}
+
+	var occurrences func(node *Node[T]) int
+	if bst.duplicates == CountDuplicates {
+		occurrences = func(node *Node[T]) int { return node.occurrences }
+	}
+
+	data, err := binfmt.Encode(binfmt.BinarySearchTree, bst.root, (*Node[T]).children, func(node *Node[T]) T { return node.data }, occurrences, codec)
+	if err != nil {
+		return nil, fmt.Errorf("method MarshalBinary() failed with error: %v", err)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary is BinarySearchTree's implementation of the encoding.BinaryUnmarshaler interface
+// It replaces the contents of the tree with the one written by MarshalBinary, keeping its exact shape, after checking
+// that the values are in binary search tree order, and that repeated values only show up with the CountDuplicates policy
+// The tree keeps its comparator, balancing mode and duplicate policy, and a self balancing tree gets rebalanced if the shape needs it
+// It leaves the tree as it was if the data is corrupted or was written by some other tree type
+func (bst *BinarySearchTree[T]) UnmarshalBinary(data []byte) error {
+	return bst.UnmarshalBinaryWithCodec(data, binfmt.DefaultCodec[T]())
+}
+
+// UnmarshalBinaryWithCodec reads a binary search tree written by MarshalBinaryWithCodec, using the same codec for the values
+func (bst *BinarySearchTree[T]) UnmarshalBinaryWithCodec(data []byte, codec ValueCodec[T]) error {
+	if bst.IsNil() {
+		return treeNilError
+	}
+
+	if codec == nil {
+		return codecNilError
+	}
+
+	compare, err := bst.comparator()
+	if err != nil {
+		return err
+	}
+
+	decoded, err := binfmt.Decode(binfmt.BinarySearchTree, data, codec)
+	if err != nil {
+		return fmt.Errorf("method UnmarshalBinary() failed with error: %v", err)
+	}
+
+	rebuilt := *bst
+	rebuilt.root = nil
+	rebuilt.count = 0
+
+	err = buildChecked(&rebuilt, decoded, describeDecodedNode, compare)
+	if err != nil {
+		return fmt.Errorf("method UnmarshalBinary() failed with error: %v", err)
+	}
+
+	*bst = rebuilt
+	return nil
+}
+
+func describeDecodedNode[T any](decoded *binfmt.Node[T]) (T, int, *binfmt.Node[T], *binfmt.Node[T], error) {
+	return decoded.Value, decoded.Count, decoded.Left, decoded.Right, nil
+}
@@ -0,0 +1,270 @@
+package bstreelib
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pluckynumbat/go-tree/internal/binfmt"
+)
+
+// negatingCodec writes every integer negated, which turns a valid binary search tree into an invalid one for the default codec
+type negatingCodec struct{}
+
+func (negatingCodec) EncodeValue(value int) ([]byte, error) {
+	return binfmt.DefaultCodec[int]().EncodeValue(-value)
+}
+
+func (negatingCodec) DecodeValue(data []byte) (int, error) {
+	value, err := binfmt.DefaultCodec[int]().DecodeValue(data)
+	return -value, err
+}
+
+func TestMarshalBinary(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		policy DuplicatePolicy
+	}{
+		{"empty tree", []int{}, RejectDuplicates},
+		{"single node", []int{1}, RejectDuplicates},
+		{"unbalanced", []int{5, 2, 8, 1, 3, 9, 10}, RejectDuplicates},
+		{"right chain", []int{1, 2, 3, 4}, RejectDuplicates},
+		{"repeated values", []int{2, 1, 2, 3, 2, 1}, CountDuplicates},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bst, err := ConstructFromValuesWithPolicy(test.policy, test.values...)
+			if err != nil {
+				t.Fatalf("ConstructFromValuesWithPolicy() failed with error: %v", err)
+			}
+
+			for _, codec := range []ValueCodec[int]{nil, negatingCodec{}} {
+				readBack := New[int]()
+				err = readBack.SetDuplicatePolicy(test.policy)
+				if err != nil {
+					t.Fatalf("SetDuplicatePolicy() failed with error: %v", err)
+				}
+
+				var data []byte
+				if codec == nil {
+					data, err = bst.MarshalBinary()
+					if err == nil {
+						err = readBack.UnmarshalBinary(data)
+					}
+				} else {
+					data, err = bst.MarshalBinaryWithCodec(codec)
+					if err == nil {
+						err = readBack.UnmarshalBinaryWithCodec(data, codec)
+					}
+				}
+				if err != nil {
+					t.Fatalf("binary round trip failed with error: %v", err)
+				}
+
+				checkParentPointers(t, readBack)
+				checkSubtreeSizes(t, readBack)
+
+				want, _ := bst.TraverseBFS()
+				got, _ := readBack.TraverseBFS()
+				if got != want {
+					t.Errorf("UnmarshalBinary() returned incorrect results, want: %v, got: %v", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGob(t *testing.T) {
+	bst, err := ConstructFromValues("m", "c", "x", "a")
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	buffer := &bytes.Buffer{}
+	err = gob.NewEncoder(buffer).Encode(bst)
+	if err != nil {
+		t.Fatalf("gob Encode() failed with error: %v", err)
+	}
+
+	// the zero value tree works for strings, so gob can decode into it
+	var readBack BinarySearchTree[string]
+	err = gob.NewDecoder(buffer).Decode(&readBack)
+	if err != nil {
+		t.Fatalf("gob Decode() failed with error: %v", err)
+	}
+
+	got, _ := readBack.TraverseBFS()
+	want, _ := bst.TraverseBFS()
+	if got != want {
+		t.Errorf("gob returned incorrect results, want: %v, got: %v", want, got)
+	}
+
+	err = readBack.Insert("b")
+	if err != nil {
+		t.Fatalf("Insert() failed with error: %v", err)
+	}
+	values, _ := readBack.ConstructOrderedSlice()
+	if !slices.Equal(values, []string{"a", "b", "c", "m", "x"}) {
+		t.Errorf("Insert() after gob returned incorrect results, got: %v", values)
+	}
+
+	// a tree held by value inside a struct, which gob can only reach when the struct is passed by pointer
+	type document struct {
+		Name string
+		Tree BinarySearchTree[string]
+	}
+
+	buffer.Reset()
+	err = gob.NewEncoder(buffer).Encode(&document{"letters", *bst})
+	if err != nil {
+		t.Fatalf("gob Encode() of a tree held by value failed with error: %v", err)
+	}
+
+	var doc document
+	err = gob.NewDecoder(buffer).Decode(&doc)
+	if err != nil {
+		t.Fatalf("gob Decode() failed with error: %v", err)
+	}
+
+	got, _ = doc.Tree.TraverseBFS()
+	if doc.Name != "letters" || got != want {
+		t.Errorf("gob returned incorrect results for a tree held by value, want: %v, got: %v", want, got)
+	}
+}
+
+func TestUnmarshalBinaryKeepsSettings(t *testing.T) {
+	chain, err := ConstructFromValues(1, 2, 3, 4, 5)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	data, err := chain.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() failed with error: %v", err)
+	}
+
+	// a comparator that orders in reverse can't read a tree ordered the usual way
+	reversed := NewWithComparator(func(a, b int) int { return b - a })
+	err = reversed.UnmarshalBinary(data)
+	if err == nil {
+		t.Errorf("UnmarshalBinary() into a tree with a reversed comparator should have failed")
+	} else {
+		fmt.Println(err)
+	}
+
+	// a self balancing tree rebalances the chain
+	avl := NewAVL[int]()
+	err = avl.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatalf("UnmarshalBinary() failed with error: %v", err)
+	}
+	checkAVLInvariants(t, avl)
+
+	height, _ := avl.Height()
+	if height != 2 {
+		t.Errorf("UnmarshalBinary() into a self balancing tree did not rebalance, want height: %v, got: %v", 2, height)
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	var nilTree *BinarySearchTree[int]
+	_, err := nilTree.MarshalBinary()
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("MarshalBinary() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	}
+
+	_, err = nilTree.MarshalBinaryWithCodec(negatingCodec{})
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("MarshalBinaryWithCodec() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	err = New[int]().UnmarshalBinaryWithCodec(nil, nil)
+	if !errors.Is(err, codecNilError) {
+		t.Errorf("UnmarshalBinaryWithCodec() without a codec should have failed with error: %v, got: %v", codecNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	err = (&BinarySearchTree[struct{ X int }]{}).UnmarshalBinary(nil)
+	if !errors.Is(err, noComparatorError) {
+		t.Errorf("UnmarshalBinary() without a comparator should have failed with error: %v, got: %v", noComparatorError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	bst, err := ConstructFromValues(5, 3, 8)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	negated, err := bst.MarshalBinaryWithCodec(negatingCodec{})
+	if err != nil {
+		t.Fatalf("MarshalBinaryWithCodec() failed with error: %v", err)
+	}
+
+	multiset, err := ConstructFromValuesWithPolicy(CountDuplicates, 5, 5)
+	if err != nil {
+		t.Fatalf("ConstructFromValuesWithPolicy() failed with error: %v", err)
+	}
+	counted, err := multiset.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() failed with error: %v", err)
+	}
+
+	// a plain binary tree with a repeated value, written straight in the binary search tree format
+	repeated, err := binfmt.Encode(binfmt.BinarySearchTree, &Node[int]{data: 5, left: &Node[int]{data: 5}}, (*Node[int]).children,
+		func(node *Node[int]) int { return node.data }, nil, binfmt.DefaultCodec[int]())
+	if err != nil {
+		t.Fatalf("Encode() failed with error: %v", err)
+	}
+
+	// the same tree, marked as a plain binary tree
+	wrongKind, err := binfmt.Encode(binfmt.BinaryTree, bst.root, (*Node[int]).children,
+		func(node *Node[int]) int { return node.data }, nil, binfmt.DefaultCodec[int]())
+	if err != nil {
+		t.Fatalf("Encode() failed with error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"out of order", negated},
+		{"counts without the policy", counted},
+		{"repeated value", repeated},
+		{"wrong kind", wrongKind},
+		{"truncated", negated[:len(negated)-1]},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			readBack, err2 := ConstructFromValues(7, 8)
+			if err2 != nil {
+				t.Fatalf("ConstructFromValues() failed with error: %v", err2)
+			}
+
+			err2 = readBack.UnmarshalBinary(test.data)
+			if err2 == nil {
+				t.Fatalf("UnmarshalBinary() should have failed")
+			}
+			if !strings.HasPrefix(err2.Error(), "method UnmarshalBinary() failed with error:") {
+				t.Errorf("UnmarshalBinary() returned an unexpected error: %v", err2)
+			}
+			fmt.Println(err2)
+
+			// a failed read leaves the tree as it was
+			got, _ := readBack.ConstructOrderedSlice()
+			if !slices.Equal(got, []int{7, 8}) {
+				t.Errorf("UnmarshalBinary() changed the tree after failing, got: %v", got)
+			}
+		})
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"cmp"
 	"fmt"
 	"github.com/pluckynumbat/go-quez/sgquezlib"
+	"github.com/pluckynumbat/go-tree/internal/ordering"
 	"slices"
+	"strings"
 )
 
 const invalidCount = -1
@@ -14,9 +16,10 @@ var nodeNilError = fmt.Errorf("the node is nil")
 var treeNilError = fmt.Errorf("the binary search tree is nil")
 var treeEmptyError = fmt.Errorf("the binary search tree is empty")
 var noValuesError = fmt.Errorf("there are no values in the input")
+var noComparatorError = fmt.Errorf("the binary search tree has no comparator for its element type")
 
 // duplicateElementError is a custom error raised when an element already present in the tree is attempted to be inserted
-type duplicateElementError[T BinarySearchTreeElement] struct {
+type duplicateElementError[T any] struct {
 	value T
 }
 
@@ -25,18 +28,33 @@ func (err duplicateElementError[T]) Error() string {
 	return fmt.Sprintf("the binary search tree already has the value attempting to be inserted: %v", err.value)
 }
 
+// elementNotFoundError is a custom error raised when an element that is not present in the tree is attempted to be deleted
+type elementNotFoundError[T any] struct {
+	value T
+}
+
+// elementNotFoundError's implementation of the Error interface
+func (err elementNotFoundError[T]) Error() string {
+	return fmt.Sprintf("the binary search tree does not have the value attempting to be deleted: %v", err.value)
+}
+
 // BinarySearchTreeElement is a custom interface that combines the constraints of the Ordered and Stringer interfaces
+// It is no longer required by the tree, any cmp.Ordered type (or any type at all, along with a comparator) can be stored
 type BinarySearchTreeElement interface {
 	cmp.Ordered
 	fmt.Stringer
 }
 
-// Node is the basic unit of the binary search tree, and contains data which can be anything that implements the BinarySearchTreeElement interface
-type Node[T BinarySearchTreeElement] struct {
+// Node is the basic unit of the binary search tree, and contains data which can be of any type the tree knows how to compare
+type Node[T any] struct {
 	data   T
 	parent *Node[T]
 	left   *Node[T]
 	right  *Node[T]
+	size   int // number of values (counting repeats) in the subtree rooted at this node
+	height int // number of nodes on the longest path from this node down to a leaf
+
+	occurrences int // number of times the value was inserted, only ever more than 1 with the CountDuplicates policy
 }
 
 // Node's implementation of the fmt.Stringer interface
@@ -44,7 +62,7 @@ func (node *Node[T]) String() string {
 	if node == nil {
 		return "nil"
 	}
-	return node.data.String()
+	return fmt.Sprintf("%v", node.data)
 }
 
 // Parent is used to get a pointer to the parent node of a given node
@@ -72,9 +90,40 @@ func (node *Node[T]) RightChild() (*Node[T], error) {
 }
 
 // BinarySearchTree struct will hold the core functionality of this library
-type BinarySearchTree[T BinarySearchTreeElement] struct {
-	root  *Node[T]
-	count int
+// The zero value is ready to use for element types whose underlying type is ordered (integers, floats and strings),
+// other element types need a tree created using NewWithComparator
+type BinarySearchTree[T any] struct {
+	root          *Node[T]
+	count         int
+	compare       func(a, b T) int
+	selfBalancing bool            // whether the tree rebalances itself (AVL style) on every insert and delete
+	duplicates    DuplicatePolicy // what Insert does with a value that is already present
+}
+
+// New returns an empty binary search tree that orders its elements using cmp.Compare
+func New[T cmp.Ordered]() *BinarySearchTree[T] {
+	return &BinarySearchTree[T]{compare: cmp.Compare[T]}
+}
+
+// NewWithComparator returns an empty binary search tree that orders its elements using the given comparator
+// The comparator should return a negative number when a < b, zero when a == b and a positive number when a > b
+func NewWithComparator[T any](compare func(a, b T) int) *BinarySearchTree[T] {
+	return &BinarySearchTree[T]{compare: compare}
+}
+
+// comparator returns the function used to order the elements of the tree,
+// falling back to the natural ordering of the element type when the tree was not given one
+func (bst *BinarySearchTree[T]) comparator() (func(a, b T) int, error) {
+	if bst.compare != nil {
+		return bst.compare, nil
+	}
+
+	compare := ordering.Comparator[T]()
+	if compare == nil {
+		return nil, noComparatorError
+	}
+
+	return compare, nil
 }
 
 // IsNil tells you if the pointer to the binary search tree is nil
@@ -95,7 +144,7 @@ func (bst *BinarySearchTree[T]) Root() *Node[T] {
 	return bst.root
 }
 
-// Count returns the number of elements in a binary search tree
+// Count returns the number of elements in a binary search tree, repeated values count once per occurrence
 func (bst *BinarySearchTree[T]) Count() (int, error) {
 	if bst.IsNil() {
 		return invalidCount, treeNilError
@@ -109,7 +158,12 @@ func (bst *BinarySearchTree[T]) Insert(value T) error {
 		return treeNilError
 	}
 
-	node := &Node[T]{value, nil, nil, nil}
+	compare, err := bst.comparator()
+	if err != nil {
+		return err
+	}
+
+	node := &Node[T]{data: value, size: 1, height: 1, occurrences: 1}
 
 	// empty tree
 	if bst.root == nil {
@@ -121,26 +175,30 @@ func (bst *BinarySearchTree[T]) Insert(value T) error {
 	runner := bst.root
 
 	for runner != nil {
-		if runner.data == value { // the value is already present
-			return duplicateElementError[T]{value}
+		result := compare(value, runner.data)
+
+		if result == 0 { // the value is already present
+			return bst.insertDuplicate(runner, value)
 		}
 
-		if runner.data > value {
+		if result < 0 {
 			if runner.left == nil { // insert as left child
 				runner.left = node
 				node.parent = runner
 				bst.count += 1
+				bst.retrace(runner)
 				return nil
 			}
 			runner = runner.left // check left subtree
 			continue
 		}
 
-		if runner.data < value {
+		if result > 0 {
 			if runner.right == nil { // insert as right child
 				runner.right = node
 				node.parent = runner
 				bst.count += 1
+				bst.retrace(runner)
 				return nil
 			}
 			runner = runner.right // check right subtree
@@ -151,8 +209,8 @@ func (bst *BinarySearchTree[T]) Insert(value T) error {
 }
 
 // ConstructFromValues is a helper function to insert all the given values (in the order that they are provided) into a binary search tree
-func ConstructFromValues[T BinarySearchTreeElement](values ...T) (*BinarySearchTree[T], error) {
-	bstree := &BinarySearchTree[T]{}
+func ConstructFromValues[T cmp.Ordered](values ...T) (*BinarySearchTree[T], error) {
+	bstree := New[T]()
 
 	for _, val := range values {
 		err := bstree.Insert(val)
@@ -188,7 +246,7 @@ func (bst *BinarySearchTree[T]) TraverseBFS() (string, error) {
 			return "", fmt.Errorf("BFS traversal failed with error: %v", err2)
 		}
 
-		treeStr += fmt.Sprintf("-(%v)-", runner.data)
+		treeStr += runner.visitString()
 
 		if runner.left != nil {
 			err2 = queue.Enqueue(runner.left)
@@ -223,13 +281,18 @@ func (bst *BinarySearchTree[T]) TraverseDFSInOrder() (string, error) {
 	return recurseDFSInOrder(bst.root), nil
 }
 
-func recurseDFSInOrder[T BinarySearchTreeElement](node *Node[T]) string {
+// visitString returns the string for visiting the node during a traversal, with the value repeated once per occurrence
+func (node *Node[T]) visitString() string {
+	return strings.Repeat(fmt.Sprintf("-(%v)-", node.data), node.occurrences)
+}
+
+func recurseDFSInOrder[T any](node *Node[T]) string {
 	if node == nil {
 		return ""
 	}
 
 	result := recurseDFSInOrder(node.left)
-	result += fmt.Sprintf("-(%v)-", node.data)
+	result += node.visitString()
 	result += recurseDFSInOrder(node.right)
 
 	return result
@@ -247,12 +310,12 @@ func (bst *BinarySearchTree[T]) TraverseDFSPreOrder() (string, error) {
 	return recurseDFSPreOrder(bst.root), nil
 }
 
-func recurseDFSPreOrder[T BinarySearchTreeElement](node *Node[T]) string {
+func recurseDFSPreOrder[T any](node *Node[T]) string {
 	if node == nil {
 		return ""
 	}
 
-	result := fmt.Sprintf("-(%v)-", node.data)
+	result := node.visitString()
 	result += recurseDFSPreOrder(node.left)
 	result += recurseDFSPreOrder(node.right)
 
@@ -271,14 +334,14 @@ func (bst *BinarySearchTree[T]) TraverseDFSPostOrder() (string, error) {
 	return recurseDFSPostOrder(bst.root), nil
 }
 
-func recurseDFSPostOrder[T BinarySearchTreeElement](node *Node[T]) string {
+func recurseDFSPostOrder[T any](node *Node[T]) string {
 	if node == nil {
 		return ""
 	}
 
 	result := recurseDFSPostOrder(node.left)
 	result += recurseDFSPostOrder(node.right)
-	result += fmt.Sprintf("-(%v)-", node.data)
+	result += node.visitString()
 
 	return result
 }
@@ -294,22 +357,153 @@ func (bst *BinarySearchTree[T]) Search(val T) (bool, error) {
 		return false, treeEmptyError
 	}
 
+	node, err := bst.findNode(val)
+	if err != nil {
+		return false, err
+	}
+
+	return node != nil, nil
+}
+
+// findNode returns a pointer to the node containing the given value, or nil if the value is not present in the tree
+func (bst *BinarySearchTree[T]) findNode(val T) (*Node[T], error) {
+	compare, err := bst.comparator()
+	if err != nil {
+		return nil, err
+	}
+
 	runner := bst.root
 
 	for runner != nil {
+		result := compare(val, runner.data)
 
-		if runner.data == val {
-			return true, nil
+		if result == 0 {
+			return runner, nil
 		}
 
-		if runner.data > val {
+		if result < 0 {
 			runner = runner.left
 		} else {
 			runner = runner.right
 		}
 	}
 
-	return false, nil
+	return nil, nil
+}
+
+// Delete removes the given value from the binary search tree
+// With the CountDuplicates policy, it removes a single occurrence of the value
+// A node with two children is replaced by its in-order successor, so pointers to all other nodes stay valid
+func (bst *BinarySearchTree[T]) Delete(value T) error {
+	if bst.IsNil() {
+		return treeNilError
+	}
+
+	if bst.IsEmpty() {
+		return treeEmptyError
+	}
+
+	node, err := bst.findNode(value)
+	if err != nil {
+		return err
+	}
+
+	if node == nil {
+		return elementNotFoundError[T]{value}
+	}
+
+	if node.occurrences > 1 {
+		node.occurrences -= 1
+		bst.count -= 1
+		bst.retrace(node)
+		return nil
+	}
+
+	bst.removeNode(node)
+	return nil
+}
+
+// removeNode takes the node (along with all the occurrences of its value) out of the tree
+func (bst *BinarySearchTree[T]) removeNode(node *Node[T]) {
+	// the lowest node whose subtree changed, all the nodes above it need to be retraced
+	lowest := node.parent
+
+	switch {
+	case node.left == nil: // leaf or only a right child
+		bst.transplant(node, node.right)
+
+	case node.right == nil: // only a left child
+		bst.transplant(node, node.left)
+
+	default: // two children, the successor is the leftmost node of the right subtree
+		successor := leftmost(node.right)
+		lowest = successor
+
+		if successor.parent != node {
+			lowest = successor.parent
+			bst.transplant(successor, successor.right)
+			successor.right = node.right
+			successor.right.parent = successor
+		}
+
+		bst.transplant(node, successor)
+		successor.left = node.left
+		successor.left.parent = successor
+	}
+
+	// detach the removed node completely
+	node.parent, node.left, node.right = nil, nil, nil
+	bst.count -= node.occurrences
+	bst.retrace(lowest)
+}
+
+// retrace walks up from the given node to the root, recomputing the subtree sizes and heights along the way,
+// and rotating any unbalanced node back into balance if the tree is self balancing
+func (bst *BinarySearchTree[T]) retrace(node *Node[T]) {
+	for runner := node; runner != nil; runner = runner.parent {
+		runner.update()
+		if bst.selfBalancing {
+			runner = bst.rebalance(runner)
+		}
+	}
+}
+
+// update recomputes the size and height of the node from those of its children
+func (node *Node[T]) update() {
+	node.size = node.occurrences + node.left.subtreeSize() + node.right.subtreeSize()
+	node.height = 1 + max(node.left.subtreeHeight(), node.right.subtreeHeight())
+}
+
+// subtreeSize returns the number of nodes in the subtree rooted at the node, which is 0 for a nil node
+func (node *Node[T]) subtreeSize() int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+// subtreeHeight returns the height of the subtree rooted at the node, which is 0 for a nil node
+func (node *Node[T]) subtreeHeight() int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+// transplant replaces the subtree rooted at the old node with the subtree rooted at the replacement node (which can be nil)
+func (bst *BinarySearchTree[T]) transplant(old, replacement *Node[T]) {
+	switch {
+	case old.parent == nil:
+		bst.root = replacement
+	case old.parent.left == old:
+		old.parent.left = replacement
+	default:
+		old.parent.right = replacement
+	}
+
+	if replacement != nil {
+		replacement.parent = old.parent
+	}
 }
 
 // ConstructOrderedSlice collects all the elements in the binary search tree in an ordered manner, and returns them in a slice
@@ -330,14 +524,16 @@ func (bst *BinarySearchTree[T]) ConstructOrderedSlice() ([]T, error) {
 	return result, nil
 }
 
-func recurseCollectInOrder[T BinarySearchTreeElement](slicePtr *[]T, node *Node[T]) {
+func recurseCollectInOrder[T any](slicePtr *[]T, node *Node[T]) {
 
 	if node == nil {
 		return
 	}
 
 	recurseCollectInOrder(slicePtr, node.left)
-	*slicePtr = append(*slicePtr, node.data)
+	for range node.occurrences {
+		*slicePtr = append(*slicePtr, node.data)
+	}
 	recurseCollectInOrder(slicePtr, node.right)
 }
 
@@ -378,7 +574,7 @@ func (bst *BinarySearchTree[T]) BalanceTree() error {
 
 // recurseInsertNode is used to insert the element present in the middle of the given range into the binary search tree
 // and then recursively do this on the 2 new ranges created on each side of the middle element
-func recurseInsertNode[T BinarySearchTreeElement](bst *BinarySearchTree[T], slice []T, min, max int) error {
+func recurseInsertNode[T any](bst *BinarySearchTree[T], slice []T, min, max int) error {
 
 	if max < min {
 		return nil
@@ -407,7 +603,7 @@ func recurseInsertNode[T BinarySearchTreeElement](bst *BinarySearchTree[T], slic
 }
 
 // ConstructBalancedTree is a helper function to insert all the given values into a binary search tree, in a manner which creates a balanced tree
-func ConstructBalancedTree[T BinarySearchTreeElement](values ...T) (*BinarySearchTree[T], error) {
+func ConstructBalancedTree[T cmp.Ordered](values ...T) (*BinarySearchTree[T], error) {
 
 	cnt := len(values)
 	if cnt == 0 {
@@ -416,7 +612,7 @@ func ConstructBalancedTree[T BinarySearchTreeElement](values ...T) (*BinarySearc
 
 	slices.Sort(values)
 
-	bst := &BinarySearchTree[T]{}
+	bst := New[T]()
 
 	// recursively insert elements from the input values into the binary search tree
 	insertErr := recurseInsertNode(bst, values, 0, cnt-1)
@@ -426,3 +622,49 @@ func ConstructBalancedTree[T BinarySearchTreeElement](values ...T) (*BinarySearc
 
 	return bst, nil
 }
+
+// ConstructFromPreOrder rebuilds a binary search tree from its pre-order traversal alone, in O(n)
+// The values have to be unique, and have to form the pre-order traversal of some binary search tree
+func ConstructFromPreOrder[T cmp.Ordered](values ...T) (*BinarySearchTree[T], error) {
+	seen := make(map[T]bool, len(values))
+	for _, val := range values {
+		if seen[val] {
+			return nil, duplicateElementError[T]{val}
+		}
+		seen[val] = true
+	}
+
+	bst := New[T]()
+
+	next := 0
+	bst.root = recurseBuildFromPreOrder(values, &next, nil, nil, nil)
+
+	// every value that fits nowhere stops the recursion, so any value left over breaks the pre-order sequence
+	if next < len(values) {
+		return nil, fmt.Errorf("the value %v at index %v does not fit the pre-order traversal of a binary search tree", values[next], next)
+	}
+
+	bst.count = len(values)
+	return bst, nil
+}
+
+// recurseBuildFromPreOrder builds the subtree whose values lie strictly between low and high (a nil bound is unbounded),
+// out of the longest run of the values starting at next, and moves next past the values it used
+func recurseBuildFromPreOrder[T cmp.Ordered](values []T, next *int, parent *Node[T], low, high *T) *Node[T] {
+	if *next >= len(values) {
+		return nil
+	}
+
+	val := values[*next]
+	if (low != nil && val < *low) || (high != nil && val > *high) {
+		return nil
+	}
+	*next += 1
+
+	node := &Node[T]{data: val, parent: parent, occurrences: 1}
+	node.left = recurseBuildFromPreOrder(values, next, node, low, &node.data)
+	node.right = recurseBuildFromPreOrder(values, next, node, &node.data, high)
+	node.update()
+
+	return node
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/pluckynumbat/go-quez/sgquezlib"
+	"slices"
+	"sync"
 	"testing"
 )
 
@@ -25,7 +27,7 @@ func (p prFloat) String() string {
 
 func TestNodeString(t *testing.T) {
 	t.Run("test node string: prInt", func(t *testing.T) {
-		node := &Node[prInt]{1, nil, nil, nil}
+		node := &Node[prInt]{data: 1}
 
 		want := "1"
 		got := node.String()
@@ -36,7 +38,7 @@ func TestNodeString(t *testing.T) {
 	})
 
 	t.Run("test node string: prString", func(t *testing.T) {
-		node := &Node[prString]{"a", nil, nil, nil}
+		node := &Node[prString]{data: "a"}
 
 		want := "a"
 		got := node.String()
@@ -47,7 +49,7 @@ func TestNodeString(t *testing.T) {
 	})
 
 	t.Run("test node string: prFloat", func(t *testing.T) {
-		node := &Node[prFloat]{3.14, nil, nil, nil}
+		node := &Node[prFloat]{data: 3.14}
 
 		want := "3.14"
 		got := node.String()
@@ -61,8 +63,8 @@ func TestNodeString(t *testing.T) {
 func TestNodeParent(t *testing.T) {
 	t.Run("test node parent: prInt", func(t *testing.T) {
 		var n1, n2, n3 *Node[prInt]
-		n2 = &Node[prInt]{1, nil, nil, nil}
-		n3 = &Node[prInt]{2, n2, nil, nil}
+		n2 = &Node[prInt]{data: 1}
+		n3 = &Node[prInt]{data: 2, parent: n2}
 
 		tests := []struct {
 			name      string
@@ -166,8 +168,8 @@ func TestNodeParent(t *testing.T) {
 
 	t.Run("test node parent: prString", func(t *testing.T) {
 		var n1, n2, n3 *Node[prString]
-		n2 = &Node[prString]{"a", nil, nil, nil}
-		n3 = &Node[prString]{"b", n2, nil, nil}
+		n2 = &Node[prString]{data: "a"}
+		n3 = &Node[prString]{data: "b", parent: n2}
 
 		tests := []struct {
 			name      string
@@ -271,8 +273,8 @@ func TestNodeParent(t *testing.T) {
 
 	t.Run("test node parent: prFloat", func(t *testing.T) {
 		var n1, n2, n3 *Node[prFloat]
-		n2 = &Node[prFloat]{1.9, nil, nil, nil}
-		n3 = &Node[prFloat]{2.1, n2, nil, nil}
+		n2 = &Node[prFloat]{data: 1.9}
+		n3 = &Node[prFloat]{data: 2.1, parent: n2}
 
 		tests := []struct {
 			name      string
@@ -378,8 +380,8 @@ func TestNodeParent(t *testing.T) {
 func TestNodeLeftChild(t *testing.T) {
 	t.Run("test node left child: prInt", func(t *testing.T) {
 		var n1, n2, n3 *Node[prInt]
-		n2 = &Node[prInt]{1, nil, nil, nil}
-		n3 = &Node[prInt]{2, n2, nil, nil}
+		n2 = &Node[prInt]{data: 1}
+		n3 = &Node[prInt]{data: 2, parent: n2}
 		n2.left = n3
 
 		tests := []struct {
@@ -484,8 +486,8 @@ func TestNodeLeftChild(t *testing.T) {
 
 	t.Run("test node left child: prString", func(t *testing.T) {
 		var n1, n2, n3 *Node[prString]
-		n2 = &Node[prString]{"a", nil, nil, nil}
-		n3 = &Node[prString]{"b", n2, nil, nil}
+		n2 = &Node[prString]{data: "a"}
+		n3 = &Node[prString]{data: "b", parent: n2}
 		n2.left = n3
 
 		tests := []struct {
@@ -590,8 +592,8 @@ func TestNodeLeftChild(t *testing.T) {
 
 	t.Run("test node left child: prFloat", func(t *testing.T) {
 		var n1, n2, n3 *Node[prFloat]
-		n2 = &Node[prFloat]{1.2, nil, nil, nil}
-		n3 = &Node[prFloat]{1.1, n2, nil, nil}
+		n2 = &Node[prFloat]{data: 1.2}
+		n3 = &Node[prFloat]{data: 1.1, parent: n2}
 		n2.left = n3
 
 		tests := []struct {
@@ -698,8 +700,8 @@ func TestNodeLeftChild(t *testing.T) {
 func TestNodeRightChild(t *testing.T) {
 	t.Run("test node right child: prInt", func(t *testing.T) {
 		var n1, n2, n3 *Node[prInt]
-		n2 = &Node[prInt]{1, nil, nil, nil}
-		n3 = &Node[prInt]{2, n2, nil, nil}
+		n2 = &Node[prInt]{data: 1}
+		n3 = &Node[prInt]{data: 2, parent: n2}
 		n2.right = n3
 
 		tests := []struct {
@@ -804,8 +806,8 @@ func TestNodeRightChild(t *testing.T) {
 
 	t.Run("test node right child: prString", func(t *testing.T) {
 		var n1, n2, n3 *Node[prString]
-		n2 = &Node[prString]{"a", nil, nil, nil}
-		n3 = &Node[prString]{"b", n2, nil, nil}
+		n2 = &Node[prString]{data: "a"}
+		n3 = &Node[prString]{data: "b", parent: n2}
 		n2.right = n3
 
 		tests := []struct {
@@ -910,8 +912,8 @@ func TestNodeRightChild(t *testing.T) {
 
 	t.Run("test node right child: prFloat", func(t *testing.T) {
 		var n1, n2, n3 *Node[prFloat]
-		n2 = &Node[prFloat]{1.1, nil, nil, nil}
-		n3 = &Node[prFloat]{1.2, n2, nil, nil}
+		n2 = &Node[prFloat]{data: 1.1}
+		n3 = &Node[prFloat]{data: 1.2, parent: n2}
 		n2.right = n3
 
 		tests := []struct {
@@ -1042,7 +1044,7 @@ func TestIsEmpty(t *testing.T) {
 	var bst1, bst2, bst3 *BinarySearchTree[prInt]
 	bst2 = &BinarySearchTree[prInt]{}
 	root := &Node[prInt]{}
-	bst3 = &BinarySearchTree[prInt]{root, 1}
+	bst3 = &BinarySearchTree[prInt]{root: root, count: 1}
 
 	tests := []struct {
 		name string
@@ -1069,20 +1071,20 @@ func TestRoot(t *testing.T) {
 
 	bst2 = &BinarySearchTree[prInt]{}
 
-	r1 := &Node[prInt]{1, nil, nil, nil}
-	bst3 = &BinarySearchTree[prInt]{r1, 1}
+	r1 := &Node[prInt]{data: 1}
+	bst3 = &BinarySearchTree[prInt]{root: r1, count: 1}
 
-	r2 := &Node[prInt]{2, nil, nil, nil}
-	n2 := &Node[prInt]{1, r2, nil, nil}
+	r2 := &Node[prInt]{data: 2}
+	n2 := &Node[prInt]{data: 1, parent: r2}
 	r2.left = n2
-	bst4 = &BinarySearchTree[prInt]{r2, 2}
+	bst4 = &BinarySearchTree[prInt]{root: r2, count: 2}
 
-	r3 := &Node[prInt]{0, nil, nil, nil}
-	n4 := &Node[prInt]{-1, r3, nil, nil}
-	n5 := &Node[prInt]{1, r3, nil, nil}
+	r3 := &Node[prInt]{data: 0}
+	n4 := &Node[prInt]{data: -1, parent: r3}
+	n5 := &Node[prInt]{data: 1, parent: r3}
 	r3.left = n4
 	r3.right = n5
-	bst5 = &BinarySearchTree[prInt]{r3, 3}
+	bst5 = &BinarySearchTree[prInt]{root: r3, count: 3}
 
 	tests := []struct {
 		name       string
@@ -2422,3 +2424,407 @@ func TestConstructBalancedTree(t *testing.T) {
 		}
 	})
 }
+
+// checkParentPointers walks the whole tree and reports any node whose children do not point back to it
+func checkParentPointers[T any](t *testing.T, bst *BinarySearchTree[T]) {
+	t.Helper()
+
+	if bst.root != nil && bst.root.parent != nil {
+		t.Errorf("the root of the tree should not have a parent, got: %v", bst.root.parent)
+	}
+
+	var recurse func(node *Node[T])
+	recurse = func(node *Node[T]) {
+		if node == nil {
+			return
+		}
+		if node.left != nil && node.left.parent != node {
+			t.Errorf("incorrect parent pointer for node %v, want: %v, got: %v", node.left, node, node.left.parent)
+		}
+		if node.right != nil && node.right.parent != node {
+			t.Errorf("incorrect parent pointer for node %v, want: %v, got: %v", node.right, node, node.right.parent)
+		}
+		recurse(node.left)
+		recurse(node.right)
+	}
+	recurse(bst.root)
+}
+
+func TestDelete(t *testing.T) {
+
+	t.Run("Delete prInt", func(t *testing.T) {
+
+		var bst *BinarySearchTree[prInt]
+		err := bst.Delete(0)
+		if err == nil {
+			t.Fatalf("Delete() on a nil tree should have failed")
+		} else {
+			fmt.Println(err)
+		}
+
+		bst = &BinarySearchTree[prInt]{}
+		err = bst.Delete(0)
+		if err == nil {
+			t.Fatalf("Delete() on an empty tree should have failed")
+		} else {
+			fmt.Println(err)
+		}
+
+		bst, err = ConstructFromValues[prInt](7, 4, 9, 5, 1, 0, 2, 8, 10)
+		if err != nil {
+			t.Fatalf("ConstructFromValues() failed with error: %v", err)
+		}
+
+		tests := []struct {
+			name     string
+			val      prInt
+			expError error
+			wantBFS  string
+			wantCnt  int
+		}{
+			{"delete absent value", 3, elementNotFoundError[prInt]{3}, "-(7)--(4)--(9)--(1)--(5)--(8)--(10)--(0)--(2)-", 9},
+			{"delete leaf", 0, nil, "-(7)--(4)--(9)--(1)--(5)--(8)--(10)--(2)-", 8},
+			{"delete node with only a right child", 1, nil, "-(7)--(4)--(9)--(2)--(5)--(8)--(10)-", 7},
+			{"delete root, successor deeper in right subtree", 7, nil, "-(8)--(4)--(9)--(2)--(5)--(10)-", 6},
+			{"delete node with only a right child 2", 9, nil, "-(8)--(4)--(10)--(2)--(5)-", 5},
+			{"delete node with two children, successor is right child", 4, nil, "-(8)--(5)--(10)--(2)-", 4},
+			{"delete root, successor is right child", 8, nil, "-(10)--(5)--(2)-", 3},
+			{"delete root with only a left child", 10, nil, "-(5)--(2)-", 2},
+			{"delete root with only a left child 2", 5, nil, "-(2)-", 1},
+			{"delete the last element", 2, nil, "", 0},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				err2 := bst.Delete(test.val)
+				if err2 != nil && !errors.Is(err2, test.expError) {
+					t.Fatalf("Delete() failed with unexpected error: %v", err2)
+				} else if err2 != nil {
+					fmt.Println(err2)
+				}
+
+				gotBFS, err2 := bst.TraverseBFS()
+				if err2 != nil && !errors.Is(err2, treeEmptyError) {
+					t.Fatalf("TraverseBFS() failed with unexpected error: %v", err2)
+				} else if gotBFS != test.wantBFS {
+					t.Errorf("Delete() gave incorrect results, want: %v, got: %v", test.wantBFS, gotBFS)
+				}
+
+				gotCnt, err2 := bst.Count()
+				if err2 != nil {
+					t.Fatalf("Count() failed with unexpected error: %v", err2)
+				} else if gotCnt != test.wantCnt {
+					t.Errorf("Delete() left an incorrect count, want: %v, got: %v", test.wantCnt, gotCnt)
+				}
+
+				checkParentPointers(t, bst)
+				checkSubtreeSizes(t, bst)
+			})
+		}
+	})
+
+	t.Run("Delete prString", func(t *testing.T) {
+
+		bst, err := ConstructFromValues[prString]("d", "b", "f", "a", "c", "e", "g")
+		if err != nil {
+			t.Fatalf("ConstructFromValues() failed with error: %v", err)
+		}
+
+		tests := []struct {
+			name     string
+			val      prString
+			expError error
+			wantIn   string
+			wantBFS  string
+		}{
+			{"delete root", "d", nil, "-(a)--(b)--(c)--(e)--(f)--(g)-", "-(e)--(b)--(f)--(a)--(c)--(g)-"},
+			{"delete absent value", "d", elementNotFoundError[prString]{"d"}, "-(a)--(b)--(c)--(e)--(f)--(g)-", "-(e)--(b)--(f)--(a)--(c)--(g)-"},
+			{"delete inner node", "b", nil, "-(a)--(c)--(e)--(f)--(g)-", "-(e)--(c)--(f)--(a)--(g)-"},
+			{"delete leaf", "g", nil, "-(a)--(c)--(e)--(f)-", "-(e)--(c)--(f)--(a)-"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				err2 := bst.Delete(test.val)
+				if err2 != nil && !errors.Is(err2, test.expError) {
+					t.Fatalf("Delete() failed with unexpected error: %v", err2)
+				} else if err2 != nil {
+					fmt.Println(err2)
+				}
+
+				gotIn, err2 := bst.TraverseDFSInOrder()
+				if err2 != nil {
+					t.Fatalf("TraverseDFSInOrder() failed with unexpected error: %v", err2)
+				} else if gotIn != test.wantIn {
+					t.Errorf("Delete() gave incorrect results, want: %v, got: %v", test.wantIn, gotIn)
+				}
+
+				gotBFS, err2 := bst.TraverseBFS()
+				if err2 != nil {
+					t.Fatalf("TraverseBFS() failed with unexpected error: %v", err2)
+				} else if gotBFS != test.wantBFS {
+					t.Errorf("Delete() gave incorrect results, want: %v, got: %v", test.wantBFS, gotBFS)
+				}
+
+				checkParentPointers(t, bst)
+			})
+		}
+	})
+}
+
+func TestNewWithComparator(t *testing.T) {
+
+	type employee struct {
+		name string
+		age  int
+	}
+	byAge := func(a, b employee) int {
+		return a.age - b.age
+	}
+
+	t.Run("plain built-in types", func(t *testing.T) {
+		bstInt, err := ConstructFromValues(5, 3, 8, 1)
+		if err != nil {
+			t.Fatalf("ConstructFromValues() failed with error: %v", err)
+		}
+
+		want := "-(5)--(3)--(8)--(1)-"
+		got, err := bstInt.TraverseBFS()
+		if err != nil {
+			t.Fatalf("TraverseBFS() failed with error: %v", err)
+		} else if got != want {
+			t.Errorf("TraverseBFS() returned incorrect results, want: %v, got: %v", want, got)
+		}
+
+		bstStr := New[string]()
+		for _, val := range []string{"m", "c", "x"} {
+			err = bstStr.Insert(val)
+			if err != nil {
+				t.Fatalf("Insert() failed with error: %v", err)
+			}
+		}
+
+		want = "-(c)--(m)--(x)-"
+		got, err = bstStr.TraverseDFSInOrder()
+		if err != nil {
+			t.Fatalf("TraverseDFSInOrder() failed with error: %v", err)
+		} else if got != want {
+			t.Errorf("TraverseDFSInOrder() returned incorrect results, want: %v, got: %v", want, got)
+		}
+	})
+
+	t.Run("struct ordered by a field", func(t *testing.T) {
+		bst := NewWithComparator(byAge)
+
+		for _, e := range []employee{{"carol", 41}, {"alice", 25}, {"bob", 33}, {"dave", 52}} {
+			err := bst.Insert(e)
+			if err != nil {
+				t.Fatalf("Insert() failed with error: %v", err)
+			}
+		}
+
+		// the comparator decides what a duplicate is
+		err := bst.Insert(employee{"eve", 33})
+		if err == nil {
+			t.Fatalf("Insert() of an element equal to one present in the tree should have failed")
+		} else {
+			fmt.Println(err)
+		}
+
+		want := "-({alice 25})--({bob 33})--({carol 41})--({dave 52})-"
+		got, err := bst.TraverseDFSInOrder()
+		if err != nil {
+			t.Fatalf("TraverseDFSInOrder() failed with error: %v", err)
+		} else if got != want {
+			t.Errorf("TraverseDFSInOrder() returned incorrect results, want: %v, got: %v", want, got)
+		}
+
+		tests := []struct {
+			name string
+			val  employee
+			want bool
+		}{
+			{"search for present age", employee{"", 25}, true},
+			{"search for absent age", employee{"alice", 26}, false},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				found, err2 := bst.Search(test.val)
+				if err2 != nil {
+					t.Fatalf("Search() failed with error: %v", err2)
+				} else if found != test.want {
+					t.Errorf("Search() returned incorrect results, want: %v, got: %v", test.want, found)
+				}
+			})
+		}
+
+		err = bst.BalanceTree()
+		if err != nil {
+			t.Fatalf("BalanceTree() failed with error: %v", err)
+		}
+
+		want = "-({bob 33})--({alice 25})--({carol 41})--({dave 52})-"
+		got, err = bst.TraverseBFS()
+		if err != nil {
+			t.Fatalf("TraverseBFS() failed with error: %v", err)
+		} else if got != want {
+			t.Errorf("BalanceTree() gave incorrect results, want: %v, got: %v", want, got)
+		}
+	})
+
+	t.Run("reversed comparator", func(t *testing.T) {
+		bst := NewWithComparator(func(a, b int) int { return b - a })
+
+		for _, val := range []int{2, 1, 3} {
+			err := bst.Insert(val)
+			if err != nil {
+				t.Fatalf("Insert() failed with error: %v", err)
+			}
+		}
+
+		want := []int{3, 2, 1}
+		got, err := bst.ConstructOrderedSlice()
+		if err != nil {
+			t.Fatalf("ConstructOrderedSlice() failed with error: %v", err)
+		} else if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("ConstructOrderedSlice() returned incorrect results, want: %v, got: %v", want, got)
+		}
+	})
+
+	t.Run("zero value tree reads do not write to it", func(t *testing.T) {
+		bst := &BinarySearchTree[int]{}
+		for _, val := range []int{5, 3, 8, 1} {
+			err := bst.Insert(val)
+			if err != nil {
+				t.Fatalf("Insert() failed with error: %v", err)
+			}
+		}
+
+		// the natural comparator gets built for every call, so concurrent readers never store it in the shared tree
+		wg := sync.WaitGroup{}
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				rank, err := bst.Rank(8)
+				if err != nil || rank != 3 {
+					t.Errorf("Rank() returned incorrect results, want: %v, got: %v (error: %v)", 3, rank, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if bst.compare != nil {
+			t.Errorf("a zero value tree should not keep the natural comparator")
+		}
+	})
+
+	t.Run("zero value tree without a natural ordering", func(t *testing.T) {
+		bst := &BinarySearchTree[employee]{}
+
+		err := bst.Insert(employee{"alice", 25})
+		if !errors.Is(err, noComparatorError) {
+			t.Fatalf("Insert() should have failed with error: %v, got: %v", noComparatorError, err)
+		} else {
+			fmt.Println(err)
+		}
+	})
+
+	t.Run("node string without a String method", func(t *testing.T) {
+		tests := []struct {
+			name string
+			node fmt.Stringer
+			want string
+		}{
+			{"int node", &Node[int]{data: 7}, "7"},
+			{"struct node", &Node[employee]{data: employee{"bob", 33}}, "{bob 33}"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got := test.node.String()
+				if got != test.want {
+					t.Errorf("Node's string returned incorrect results, want: %v, got %v", test.want, got)
+				}
+			})
+		}
+	})
+}
+
+func TestConstructFromPreOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      []prInt
+		expError   bool
+		expBFSStr  string
+		expInOrder string
+	}{
+		{"empty input", []prInt{}, false, "", ""},
+		{"single value", []prInt{1}, false, "-(1)-", "-(1)-"},
+		{"balanced tree", []prInt{4, 2, 1, 3, 6, 5, 7}, false, "-(4)--(2)--(6)--(1)--(3)--(5)--(7)-", "-(1)--(2)--(3)--(4)--(5)--(6)--(7)-"},
+		{"left leaning", []prInt{5, 4, 3, 2, 1}, false, "-(5)--(4)--(3)--(2)--(1)-", "-(1)--(2)--(3)--(4)--(5)-"},
+		{"right leaning", []prInt{1, 2, 3, 4, 5}, false, "-(1)--(2)--(3)--(4)--(5)-", "-(1)--(2)--(3)--(4)--(5)-"},
+		{"zig zag", []prInt{10, 2, 8, 4, 6}, false, "-(10)--(2)--(8)--(4)--(6)-", "-(2)--(4)--(6)--(8)--(10)-"},
+		{"duplicate values", []prInt{4, 2, 4}, true, "", ""},
+		{"not a pre-order sequence", []prInt{5, 3, 4, 2}, true, "", ""},
+		{"smaller value after a right turn", []prInt{2, 3, 1}, true, "", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bst, err := ConstructFromPreOrder(test.input...)
+			if test.expError {
+				if err == nil {
+					t.Fatalf("ConstructFromPreOrder() should have failed for the input: %v", test.input)
+				}
+				fmt.Println(err)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ConstructFromPreOrder() failed with error: %v", err)
+			}
+
+			if len(test.input) == 0 {
+				if !bst.IsEmpty() {
+					t.Errorf("ConstructFromPreOrder() with no values should give an empty tree")
+				}
+				return
+			}
+
+			gotBFS, err := bst.TraverseBFS()
+			if err != nil {
+				t.Fatalf("TraverseBFS() failed with error: %v", err)
+			} else if gotBFS != test.expBFSStr {
+				t.Errorf("TraverseBFS() returned incorrect results, want: %v, got: %v", test.expBFSStr, gotBFS)
+			}
+
+			gotInOrder, err := bst.TraverseDFSInOrder()
+			if err != nil {
+				t.Fatalf("TraverseDFSInOrder() failed with error: %v", err)
+			} else if gotInOrder != test.expInOrder {
+				t.Errorf("TraverseDFSInOrder() returned incorrect results, want: %v, got: %v", test.expInOrder, gotInOrder)
+			}
+
+			if gotPreOrder := slices.Collect(bst.PreOrder()); !slices.Equal(gotPreOrder, test.input) {
+				t.Errorf("PreOrder() returned incorrect results, want: %v, got: %v", test.input, gotPreOrder)
+			}
+
+			checkParentPointers(t, bst)
+			checkSubtreeSizes(t, bst)
+
+			// the rebuilt tree should keep working like any other binary search tree
+			err = bst.Insert(100)
+			if err != nil {
+				t.Fatalf("Insert() failed with error: %v", err)
+			}
+			checkSubtreeSizes(t, bst)
+		})
+	}
+
+	_, err := ConstructFromPreOrder(4, 2, 4)
+	if !errors.Is(err, duplicateElementError[int]{4}) {
+		t.Errorf("ConstructFromPreOrder() should have failed with error: %v, got: %v", duplicateElementError[int]{4}, err)
+	}
+}
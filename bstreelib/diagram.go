@@ -0,0 +1,35 @@
+package bstreelib
+
+import (
+	"github.com/pluckynumbat/go-tree/internal/render"
+)
+
+// NodeAttributes are optional settings for a single node of an exported diagram, like "color" or "label"
+// A "label" attribute replaces the node's value as its text
+type NodeAttributes = render.Attributes
+
+// ExportDOT returns the binary search tree as a Graphviz digraph, with the edges labelled L or R,
+// and invisible nodes in place of missing children, so each child is drawn on its own side
+// The attributes function, which can be nil, gives the extra Graphviz attributes of each node (color, shape, style...)
+func (bst *BinarySearchTree[T]) ExportDOT(attributes func(node *Node[T]) NodeAttributes) (string, error) {
+	return bst.exportDiagram(render.DOT[*Node[T]], attributes)
+}
+
+// ExportMermaid returns the binary search tree as a Mermaid flowchart (graph TD), with the edges labelled L or R,
+// and hidden nodes in place of missing children, so each child is drawn on its own side
+// The attributes function, which can be nil, gives the extra style of each node, as CSS properties (fill, stroke, color...)
+func (bst *BinarySearchTree[T]) ExportMermaid(attributes func(node *Node[T]) NodeAttributes) (string, error) {
+	return bst.exportDiagram(render.Mermaid[*Node[T]], attributes)
+}
+
+// exportDiagram writes the binary search tree in one of the diagram formats, once it is sure there is something to draw
+func (bst *BinarySearchTree[T]) exportDiagram(format render.Format[*Node[T]], attributes func(node *Node[T]) NodeAttributes) (string, error) {
+	if bst.IsNil() {
+		return "", treeNilError
+	}
+
+	if bst.IsEmpty() {
+		return "", treeEmptyError
+	}
+	return format(bst.root, (*Node[T]).children, (*Node[T]).label, attributes), nil
+}
@@ -0,0 +1,92 @@
+package bstreelib
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestExportDOT(t *testing.T) {
+	bst, err := ConstructFromValuesWithPolicy(CountDuplicates, 2, 1, 3, 3)
+	if err != nil {
+		t.Fatalf("ConstructFromValuesWithPolicy() failed with error: %v", err)
+	}
+
+	got, err := bst.ExportDOT(nil)
+	if err != nil {
+		t.Fatalf("ExportDOT() failed with error: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"digraph {",
+		"\tgraph [ordering=out];",
+		"\tn0 [label=\"2\"];",
+		"\tn0 -> n1 [label=L];",
+		"\tn1 [label=\"1\"];",
+		"\tn0 -> n2 [label=R];",
+		"\tn2 [label=\"3 (x2)\"];",
+		"}",
+	}, "\n") + "\n"
+	if got != want {
+		t.Errorf("ExportDOT() returned incorrect results, want:\n%v\ngot:\n%v", want, got)
+	}
+}
+
+func TestExportMermaid(t *testing.T) {
+	bst, err := ConstructFromValues(2, 3, 1, 4)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	// label the nodes with their rank, and color the root
+	ranks := func(node *Node[int]) NodeAttributes {
+		rank, _ := bst.Rank(node.data)
+		attributes := NodeAttributes{"label": fmt.Sprintf("#%v: %v", rank, node.data)}
+		if node == bst.Root() {
+			attributes["fill"] = "#fc0"
+		}
+		return attributes
+	}
+
+	got, err := bst.ExportMermaid(ranks)
+	if err != nil {
+		t.Fatalf("ExportMermaid() failed with error: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"graph TD",
+		"\tn0[\"#1: 2\"]",
+		"\tn0 -->|L| n1",
+		"\tn1[\"#0: 1\"]",
+		"\tn0 -->|R| n2",
+		"\tn2[\"#2: 3\"]",
+		"\tnil3[\" \"]:::invisible",
+		"\tn2 ~~~ nil3",
+		"\tn2 -->|R| n4",
+		"\tn4[\"#3: 4\"]",
+		"\tstyle n0 fill:#fc0",
+		"\tclassDef invisible display:none;",
+	}, "\n") + "\n"
+	if got != want {
+		t.Errorf("ExportMermaid() returned incorrect results, want:\n%v\ngot:\n%v", want, got)
+	}
+}
+
+func TestExportErrors(t *testing.T) {
+	var nilTree *BinarySearchTree[int]
+
+	_, err := nilTree.ExportMermaid(nil)
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("ExportMermaid() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	_, err = New[int]().ExportDOT(nil)
+	if !errors.Is(err, treeEmptyError) {
+		t.Errorf("ExportDOT() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	} else {
+		fmt.Println(err)
+	}
+}
@@ -0,0 +1,61 @@
+package bstreelib
+
+import (
+	"iter"
+
+	"github.com/pluckynumbat/go-tree/internal/walk"
+)
+
+// All returns an iterator over the values of the binary search tree, in ascending order
+func (bst *BinarySearchTree[T]) All() iter.Seq[T] {
+	return bst.InOrder()
+}
+
+// BFS returns an iterator over the values of the binary search tree using Breadth First Search
+// Breaking out of the range loop stops the traversal immediately
+func (bst *BinarySearchTree[T]) BFS() iter.Seq[T] {
+	return bst.values(walk.BFS[*Node[T]])
+}
+
+// PreOrder returns an iterator over the values of the binary search tree using Depth First Search
+// In a pre-order manner (visit a node, then its left subtree, followed by its right subtree)
+func (bst *BinarySearchTree[T]) PreOrder() iter.Seq[T] {
+	return bst.values(walk.PreOrder[*Node[T]])
+}
+
+// InOrder returns an iterator over the values of the binary search tree using Depth First Search
+// In an in-order manner (visit a node's left subtree, then the node itself, followed by its right subtree)
+func (bst *BinarySearchTree[T]) InOrder() iter.Seq[T] {
+	return bst.values(walk.InOrder[*Node[T]])
+}
+
+// PostOrder returns an iterator over the values of the binary search tree using Depth First Search
+// In a post-order manner (visit a node's left subtree, then the node's right subtree, finally the node itself)
+func (bst *BinarySearchTree[T]) PostOrder() iter.Seq[T] {
+	return bst.values(walk.PostOrder[*Node[T]])
+}
+
+// values walks the binary search tree in the given order, yielding the value of every node it visits once per occurrence
+func (bst *BinarySearchTree[T]) values(order walk.Order[*Node[T]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if bst.IsEmpty() {
+			return
+		}
+
+		for node := range order(bst.root, (*Node[T]).children) {
+			if !node.yieldOccurrences(yield) {
+				return
+			}
+		}
+	}
+}
+
+// yieldOccurrences yields the node's value once per occurrence, and returns false if the loop body asked to stop
+func (node *Node[T]) yieldOccurrences(yield func(T) bool) bool {
+	for range node.occurrences {
+		if !yield(node.data) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package bstreelib
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestIterators(t *testing.T) {
+	bst, err := ConstructFromValues[prInt](4, 2, 6, 1, 3, 5, 7)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		seq  func(bst *BinarySearchTree[prInt]) iter.Seq[prInt]
+		want []prInt
+	}{
+		{"All", (*BinarySearchTree[prInt]).All, []prInt{1, 2, 3, 4, 5, 6, 7}},
+		{"BFS", (*BinarySearchTree[prInt]).BFS, []prInt{4, 2, 6, 1, 3, 5, 7}},
+		{"PreOrder", (*BinarySearchTree[prInt]).PreOrder, []prInt{4, 2, 1, 3, 6, 5, 7}},
+		{"InOrder", (*BinarySearchTree[prInt]).InOrder, []prInt{1, 2, 3, 4, 5, 6, 7}},
+		{"PostOrder", (*BinarySearchTree[prInt]).PostOrder, []prInt{1, 3, 2, 5, 7, 6, 4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var nilTree *BinarySearchTree[prInt]
+			if got := slices.Collect(test.seq(nilTree)); len(got) != 0 {
+				t.Errorf("%v() on a nil tree should not yield any values, got: %v", test.name, got)
+			}
+
+			if got := slices.Collect(test.seq(&BinarySearchTree[prInt]{})); len(got) != 0 {
+				t.Errorf("%v() on an empty tree should not yield any values, got: %v", test.name, got)
+			}
+
+			got := slices.Collect(test.seq(bst))
+			if !slices.Equal(got, test.want) {
+				t.Errorf("%v() returned incorrect results, want: %v, got: %v", test.name, test.want, got)
+			}
+
+			// breaking out of the loop should stop the walk right away
+			visited := make([]prInt, 0, 3)
+			for val := range test.seq(bst) {
+				visited = append(visited, val)
+				if len(visited) == 3 {
+					break
+				}
+			}
+			if !slices.Equal(visited, test.want[:3]) {
+				t.Errorf("%v() returned incorrect results after breaking early, want: %v, got: %v", test.name, test.want[:3], visited)
+			}
+		})
+	}
+
+	t.Run("iterators agree with traversal strings", func(t *testing.T) {
+		strs, err := ConstructFromValues("m", "f", "t", "a", "h", "z")
+		if err != nil {
+			t.Fatalf("ConstructFromValues() failed with error: %v", err)
+		}
+
+		traversals := []struct {
+			name     string
+			seq      iter.Seq[string]
+			traverse func() (string, error)
+		}{
+			{"BFS", strs.BFS(), strs.TraverseBFS},
+			{"PreOrder", strs.PreOrder(), strs.TraverseDFSPreOrder},
+			{"InOrder", strs.InOrder(), strs.TraverseDFSInOrder},
+			{"PostOrder", strs.PostOrder(), strs.TraverseDFSPostOrder},
+		}
+
+		for _, traversal := range traversals {
+			want, err2 := traversal.traverse()
+			if err2 != nil {
+				t.Fatalf("traversal failed with error: %v", err2)
+			}
+
+			got := ""
+			for val := range traversal.seq {
+				got += "-(" + val + ")-"
+			}
+
+			if got != want {
+				t.Errorf("%v() returned incorrect results, want: %v, got: %v", traversal.name, want, got)
+			}
+		}
+	})
+}
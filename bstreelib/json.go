@@ -0,0 +1,201 @@
+package bstreelib
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pluckynumbat/go-tree/internal/shape"
+)
+
+// jsonNode is the nested JSON form of a node: {"value":1,"left":{...},"right":{...}}, with a missing child left out
+// Count is only written for a value stored more than once (with the CountDuplicates policy)
+type jsonNode[T any] struct {
+	Value *T           `json:"value"`
+	Count int          `json:"count,omitempty"`
+	Left  *jsonNode[T] `json:"left,omitempty"`
+	Right *jsonNode[T] `json:"right,omitempty"`
+}
+
+func recurseToJSON[T any](node *Node[T]) *jsonNode[T] {
+	if node == nil {
+		return nil
+	}
+
+	jNode := &jsonNode[T]{Value: &node.data, Left: recurseToJSON(node.left), Right: recurseToJSON(node.right)}
+	if node.occurrences > 1 {
+		jNode.Count = node.occurrences
+	}
+	return jNode
+}
+
+// MarshalJSON is BinarySearchTree's implementation of the json.Marshaler interface
+// The tree is written as nested nodes, like {"value":2,"left":{"value":1},"right":{"value":3}},
+// where a missing child is left out, and an empty tree is written as null
+// The comparator, balancing mode and duplicate policy are not written, they belong to the tree the JSON gets read into
+// encoding/json only uses it on a tree it can take the address of, so a struct holding a BinarySearchTree by value
+// has to be passed to json.Marshal by pointer (or hold a *BinarySearchTree instead)
+func (bst *BinarySearchTree[T]) MarshalJSON() ([]byte, error) {
+	if bst.IsNil() {
+		return nil, treeNilError
+	}
+
+	data, err := json.Marshal(recurseToJSON(bst.root))
+	if err != nil {
+		return nil, fmt.Errorf("method MarshalJSON() failed with error: %v", err)
+	}
+	return data, nil
+}
+
+// MarshalSortedJSON writes the binary search tree in its compact form, a sorted JSON array of its values like [1,2,3]
+// Reading the array back with UnmarshalJSON gives a balanced tree, whatever the shape of the original one
+func (bst *BinarySearchTree[T]) MarshalSortedJSON() ([]byte, error) {
+	values, err := bst.ConstructOrderedSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("method MarshalSortedJSON() failed with error: %v", err)
+	}
+	return data, nil
+}
+
+// UnmarshalJSON is BinarySearchTree's implementation of the json.Unmarshaler interface
+// It replaces the contents of the tree with either form: the nested nodes written by MarshalJSON, which keep their exact shape,
+// or the sorted array written by MarshalSortedJSON, which gets rebuilt into a balanced tree
+// The values have to be in binary search tree order, and repeated values are rejected unless the tree has the CountDuplicates policy
+// The tree keeps its comparator, balancing mode and duplicate policy, and a self balancing tree gets rebalanced if the nested shape needs it
+// encoding/json does not read input nested more than 10000 levels deep, so use the sorted form for trees deeper than that
+func (bst *BinarySearchTree[T]) UnmarshalJSON(data []byte) error {
+	if bst.IsNil() {
+		return treeNilError
+	}
+
+	compare, err := bst.comparator()
+	if err != nil {
+		return err
+	}
+
+	rebuilt := *bst
+	rebuilt.root = nil
+	rebuilt.count = 0
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		err = rebuilt.unmarshalSorted(data, compare)
+	} else {
+		err = rebuilt.unmarshalNested(data, compare)
+	}
+	if err != nil {
+		return fmt.Errorf("method UnmarshalJSON() failed with error: %v", err)
+	}
+
+	*bst = rebuilt
+	return nil
+}
+
+// unmarshalSorted fills the empty tree with the values of a sorted JSON array, inserting the middle value of every range first
+func (bst *BinarySearchTree[T]) unmarshalSorted(data []byte, compare func(a, b T) int) error {
+	var values []T
+	err := json.Unmarshal(data, &values)
+	if err != nil {
+		return err
+	}
+
+	for i := 1; i < len(values); i++ {
+		result := compare(values[i-1], values[i])
+		if result > 0 {
+			return fmt.Errorf("the values are not sorted, %v comes before %v", values[i-1], values[i])
+		}
+		if result == 0 && bst.duplicates != CountDuplicates {
+			return duplicateElementError[T]{values[i]}
+		}
+	}
+
+	return recurseInsertNode(bst, values, 0, len(values)-1)
+}
+
+// unmarshalNested fills the empty tree with the nested JSON nodes, checking that they are in binary search tree order
+func (bst *BinarySearchTree[T]) unmarshalNested(data []byte, compare func(a, b T) int) error {
+	var jRoot *jsonNode[T]
+	err := json.Unmarshal(data, &jRoot)
+	if err != nil {
+		return err
+	}
+	return buildChecked(bst, jRoot, describeJSONNode, compare)
+}
+
+func describeJSONNode[T any](jNode *jsonNode[T]) (T, int, *jsonNode[T], *jsonNode[T], error) {
+	var val T
+	if jNode.Value == nil {
+		return val, 0, nil, nil, fmt.Errorf("has no value")
+	}
+
+	if jNode.Count < 0 {
+		return val, 0, nil, nil, fmt.Errorf("has a negative count: %v", jNode.Count)
+	}
+	return *jNode.Value, max(1, jNode.Count), jNode.Left, jNode.Right, nil
+}
+
+// buildChecked fills the empty tree with the nodes read back from some encoded form, checking that they are in binary search tree order,
+// and rebalances a self balancing tree whose new shape is out of balance
+// describe gives the value of a source node, the number of times it occurs, and its children (the zero S for no child),
+// or an error that completes a sentence about the node, like "has no value"
+func buildChecked[T any, S comparable](bst *BinarySearchTree[T], root S, describe func(S) (T, int, S, S, error), compare func(a, b T) int) error {
+	var err error
+	bst.root, err = recurseBuildChecked(bst, root, describe, nil, nil, nil, compare)
+	if err != nil {
+		return err
+	}
+
+	if bst.selfBalancing && !shape.IsHeightBalanced(bst.root, (*Node[T]).children) {
+		return bst.BalanceTree()
+	}
+	return nil
+}
+
+// recurseBuildChecked builds the subtree described by the source node, whose values have to lie strictly between low and high
+// (a nil bound is unbounded), and adds its values to the count of the tree
+func recurseBuildChecked[T any, S comparable](bst *BinarySearchTree[T], src S, describe func(S) (T, int, S, S, error), parent *Node[T], low, high *T, compare func(a, b T) int) (*Node[T], error) {
+	var none S
+	if src == none {
+		return nil, nil
+	}
+
+	val, count, left, right, err := describe(src)
+	if err != nil {
+		if parent == nil {
+			return nil, fmt.Errorf("the root node %v", err)
+		}
+		return nil, fmt.Errorf("a child of the node %v %v", parent, err)
+	}
+
+	for _, bound := range []*T{low, high} {
+		if bound != nil && compare(val, *bound) == 0 {
+			return nil, duplicateElementError[T]{val}
+		}
+	}
+	if (low != nil && compare(val, *low) < 0) || (high != nil && compare(val, *high) > 0) {
+		return nil, fmt.Errorf("the value %v is out of binary search tree order under the node %v", val, parent)
+	}
+
+	if count > 1 && bst.duplicates != CountDuplicates {
+		return nil, duplicateElementError[T]{val}
+	}
+	node := &Node[T]{data: val, parent: parent, occurrences: count}
+
+	node.left, err = recurseBuildChecked(bst, left, describe, node, low, &node.data, compare)
+	if err != nil {
+		return nil, err
+	}
+
+	node.right, err = recurseBuildChecked(bst, right, describe, node, &node.data, high, compare)
+	if err != nil {
+		return nil, err
+	}
+
+	node.update()
+	bst.count += node.occurrences
+	return node, nil
+}
@@ -0,0 +1,268 @@
+package bstreelib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		policy DuplicatePolicy
+		nested string
+		sorted string
+	}{
+		{"empty tree", []int{}, RejectDuplicates, `null`, `[]`},
+		{"single node", []int{1}, RejectDuplicates, `{"value":1}`, `[1]`},
+		{"balanced", []int{2, 1, 3}, RejectDuplicates, `{"value":2,"left":{"value":1},"right":{"value":3}}`, `[1,2,3]`},
+		{"right chain", []int{1, 2, 3}, RejectDuplicates, `{"value":1,"right":{"value":2,"right":{"value":3}}}`, `[1,2,3]`},
+		{"repeated values", []int{2, 1, 2, 1, 2}, CountDuplicates, `{"value":2,"count":3,"left":{"value":1,"count":2}}`, `[1,1,2,2,2]`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bst := New[int]()
+			err := bst.SetDuplicatePolicy(test.policy)
+			if err != nil {
+				t.Fatalf("SetDuplicatePolicy() failed with error: %v", err)
+			}
+			for _, val := range test.values {
+				err = bst.Insert(val)
+				if err != nil {
+					t.Fatalf("Insert() failed with error: %v", err)
+				}
+			}
+
+			nested, err := json.Marshal(bst)
+			if err != nil {
+				t.Fatalf("json.Marshal() failed with error: %v", err)
+			}
+			if string(nested) != test.nested {
+				t.Errorf("json.Marshal() returned incorrect results, want: %v, got: %v", test.nested, string(nested))
+			}
+
+			sorted, err := bst.MarshalSortedJSON()
+			if err != nil {
+				t.Fatalf("MarshalSortedJSON() failed with error: %v", err)
+			}
+			if string(sorted) != test.sorted {
+				t.Errorf("MarshalSortedJSON() returned incorrect results, want: %v, got: %v", test.sorted, string(sorted))
+			}
+
+			// the nested form comes back with the same shape, and the sorted form comes back balanced
+			for _, data := range [][]byte{nested, sorted} {
+				readBack := &BinarySearchTree[int]{}
+				err = readBack.SetDuplicatePolicy(test.policy)
+				if err != nil {
+					t.Fatalf("SetDuplicatePolicy() failed with error: %v", err)
+				}
+
+				err = json.Unmarshal(data, readBack)
+				if err != nil {
+					t.Fatalf("json.Unmarshal() failed with error: %v", err)
+				}
+				checkParentPointers(t, readBack)
+				checkSubtreeSizes(t, readBack)
+
+				gotValues, _ := readBack.ConstructOrderedSlice()
+				wantValues, _ := bst.ConstructOrderedSlice()
+				if !slices.Equal(gotValues, wantValues) {
+					t.Errorf("json.Unmarshal() returned incorrect values, want: %v, got: %v", wantValues, gotValues)
+				}
+
+				gotCount, _ := readBack.Count()
+				if gotCount != len(test.values) {
+					t.Errorf("Count() after json.Unmarshal() returned incorrect results, want: %v, got: %v", len(test.values), gotCount)
+				}
+			}
+
+			readBack := &BinarySearchTree[int]{}
+			_ = readBack.SetDuplicatePolicy(test.policy)
+			_ = json.Unmarshal(nested, readBack)
+			want, _ := bst.TraverseBFS()
+			got, _ := readBack.TraverseBFS()
+			if got != want {
+				t.Errorf("json.Unmarshal() of the nested form changed the shape, want: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestMarshalJSONField(t *testing.T) {
+	// a tree held by value inside a struct, which encoding/json can only reach when the struct is passed by pointer
+	type document struct {
+		Name string                `json:"name"`
+		Tree BinarySearchTree[int] `json:"tree"`
+	}
+
+	bst, err := ConstructFromValues(2, 1, 3)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	got, err := json.Marshal(&document{"numbers", *bst})
+	if err != nil {
+		t.Fatalf("json.Marshal() failed with error: %v", err)
+	}
+
+	want := `{"name":"numbers","tree":{"value":2,"left":{"value":1},"right":{"value":3}}}`
+	if string(got) != want {
+		t.Errorf("json.Marshal() returned incorrect results, want: %v, got: %v", want, string(got))
+	}
+
+	var doc document
+	err = json.Unmarshal(got, &doc)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() failed with error: %v", err)
+	}
+	checkParentPointers(t, &doc.Tree)
+	checkSubtreeSizes(t, &doc.Tree)
+
+	gotTraversal, _ := doc.Tree.TraverseBFS()
+	wantTraversal, _ := bst.TraverseBFS()
+	if gotTraversal != wantTraversal {
+		t.Errorf("json.Unmarshal() returned incorrect results, want: %v, got: %v", wantTraversal, gotTraversal)
+	}
+}
+
+func TestUnmarshalSortedJSONBalances(t *testing.T) {
+	values := make([]int, 100)
+	for i := range values {
+		values[i] = i
+	}
+
+	bst, err := ConstructFromValues(values...)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	data, err := bst.MarshalSortedJSON()
+	if err != nil {
+		t.Fatalf("MarshalSortedJSON() failed with error: %v", err)
+	}
+
+	readBack := New[int]()
+	err = json.Unmarshal(data, readBack)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() failed with error: %v", err)
+	}
+
+	height, err := readBack.Height()
+	if err != nil {
+		t.Fatalf("Height() failed with error: %v", err)
+	}
+	if height != 6 {
+		t.Errorf("json.Unmarshal() of the sorted form did not balance the tree, want height: %v, got: %v", 6, height)
+	}
+}
+
+func TestUnmarshalJSONKeepsSettings(t *testing.T) {
+	// a comparator that orders by length first, which the JSON knows nothing about
+	byLength := func(a, b string) int {
+		if len(a) != len(b) {
+			return len(a) - len(b)
+		}
+		return strings.Compare(a, b)
+	}
+
+	bst := NewAVLWithComparator(byLength)
+	err := json.Unmarshal([]byte(`{"value":"a","right":{"value":"bb","right":{"value":"ccc"}}}`), bst)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() failed with error: %v", err)
+	}
+
+	if !bst.IsSelfBalancing() {
+		t.Errorf("json.Unmarshal() did not keep the balancing mode")
+	}
+
+	// the nested chain is not height balanced, so the self balancing tree rebalances it
+	got, err := bst.TraverseBFS()
+	if err != nil {
+		t.Fatalf("TraverseBFS() failed with error: %v", err)
+	}
+	if got != "-(bb)--(a)--(ccc)-" {
+		t.Errorf("json.Unmarshal() returned incorrect results, want: %v, got: %v", "-(bb)--(a)--(ccc)-", got)
+	}
+
+	err = bst.Insert("dddd")
+	if err != nil {
+		t.Fatalf("Insert() failed with error: %v", err)
+	}
+	checkAVLInvariants(t, bst)
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	var nilTree *BinarySearchTree[int]
+	err := nilTree.UnmarshalJSON([]byte(`[1]`))
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("UnmarshalJSON() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	_, err = nilTree.MarshalJSON()
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("MarshalJSON() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	}
+
+	_, err = nilTree.MarshalSortedJSON()
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("MarshalSortedJSON() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	err = (&BinarySearchTree[struct{ X int }]{}).UnmarshalJSON([]byte(`[]`))
+	if !errors.Is(err, noComparatorError) {
+		t.Errorf("UnmarshalJSON() without a comparator should have failed with error: %v, got: %v", noComparatorError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	tests := []struct {
+		name   string
+		data   string
+		policy DuplicatePolicy
+	}{
+		{"invalid json", `{"value":1`, RejectDuplicates},
+		{"unsorted array", `[1,3,2]`, RejectDuplicates},
+		{"repeated value in array", `[1,2,2]`, RejectDuplicates},
+		{"repeated value in array ignoring duplicates", `[1,2,2]`, IgnoreDuplicates},
+		{"left child too big", `{"value":2,"left":{"value":3}}`, RejectDuplicates},
+		{"grandchild out of order", `{"value":5,"left":{"value":2,"right":{"value":7}}}`, RejectDuplicates},
+		{"repeated value in nodes", `{"value":5,"left":{"value":2,"right":{"value":5}}}`, CountDuplicates},
+		{"count without the policy", `{"value":5,"count":2}`, RejectDuplicates},
+		{"negative count", `{"value":5,"count":-1}`, CountDuplicates},
+		{"node without a value", `{"value":5,"left":{"right":{"value":1}}}`, RejectDuplicates},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bst, err2 := ConstructFromValues(7, 8)
+			if err2 != nil {
+				t.Fatalf("ConstructFromValues() failed with error: %v", err2)
+			}
+			err2 = bst.SetDuplicatePolicy(test.policy)
+			if err2 != nil {
+				t.Fatalf("SetDuplicatePolicy() failed with error: %v", err2)
+			}
+
+			err2 = json.Unmarshal([]byte(test.data), bst)
+			if err2 == nil {
+				t.Fatalf("json.Unmarshal() should have failed for: %v", test.data)
+			}
+			fmt.Println(err2)
+
+			// a failed read leaves the tree as it was
+			got, _ := bst.ConstructOrderedSlice()
+			if !slices.Equal(got, []int{7, 8}) {
+				t.Errorf("json.Unmarshal() changed the tree after failing, got: %v", got)
+			}
+		})
+	}
+}
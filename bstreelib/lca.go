@@ -0,0 +1,45 @@
+package bstreelib
+
+import "fmt"
+
+// LowestCommonAncestor returns the value of the deepest node that has both of the given values in its subtree, in O(h)
+// Walking down from the root, it is the first node whose value lies between the two (a node is its own ancestor)
+func (bst *BinarySearchTree[T]) LowestCommonAncestor(a, b T) (T, error) {
+	var zero T
+	if bst.IsNil() {
+		return zero, treeNilError
+	}
+
+	if bst.IsEmpty() {
+		return zero, treeEmptyError
+	}
+
+	compare, err := bst.comparator()
+	if err != nil {
+		return zero, err
+	}
+
+	for _, val := range []T{a, b} {
+		node, err2 := bst.findNode(val)
+		if err2 != nil {
+			return zero, err2
+		}
+
+		if node == nil {
+			return zero, fmt.Errorf("the value %v is not in the binary search tree", val)
+		}
+	}
+
+	runner := bst.root
+	for {
+		resultA, resultB := compare(a, runner.data), compare(b, runner.data)
+
+		if resultA < 0 && resultB < 0 {
+			runner = runner.left
+		} else if resultA > 0 && resultB > 0 {
+			runner = runner.right
+		} else {
+			return runner.data, nil
+		}
+	}
+}
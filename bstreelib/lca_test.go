@@ -0,0 +1,73 @@
+package bstreelib
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestLowestCommonAncestor(t *testing.T) {
+	var nilTree *BinarySearchTree[int]
+	_, err := nilTree.LowestCommonAncestor(1, 2)
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("LowestCommonAncestor() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	_, err = New[int]().LowestCommonAncestor(1, 2)
+	if !errors.Is(err, treeEmptyError) {
+		t.Errorf("LowestCommonAncestor() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	//          8
+	//       /     \
+	//      4       12
+	//     / \     /  \
+	//    2   6   10   14
+	//   / \
+	//  1   3
+	bst, err := ConstructFromValues(8, 4, 12, 2, 6, 10, 14, 1, 3)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		a, b    int
+		want    int
+		wantErr bool
+	}{
+		{"siblings", 1, 3, 2, false},
+		{"cousins", 1, 6, 4, false},
+		{"across the root", 3, 14, 8, false},
+		{"ancestor and descendant", 4, 3, 4, false},
+		{"descendant and ancestor", 3, 4, 4, false},
+		{"same value", 10, 10, 10, false},
+		{"root and leaf", 8, 1, 8, false},
+		{"right subtree", 10, 14, 12, false},
+		{"first value missing", 5, 3, 0, true},
+		{"second value missing", 3, 5, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err2 := bst.LowestCommonAncestor(test.a, test.b)
+			if test.wantErr {
+				if err2 == nil {
+					t.Fatalf("LowestCommonAncestor() should have failed for the values %v and %v", test.a, test.b)
+				}
+				fmt.Println(err2)
+				return
+			}
+
+			if err2 != nil {
+				t.Fatalf("LowestCommonAncestor() failed with error: %v", err2)
+			} else if got != test.want {
+				t.Errorf("LowestCommonAncestor() returned incorrect results, want: %v, got: %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package bstreelib
+
+import (
+	"github.com/pluckynumbat/go-tree/internal/shape"
+)
+
+// children returns both children of the node, which is how the shape measurements walk the tree
+func (node *Node[T]) children() (*Node[T], *Node[T]) {
+	return node.left, node.right
+}
+
+// checkMeasurable returns the error for a tree that is nil or empty, since there is no shape to measure
+func (bst *BinarySearchTree[T]) checkMeasurable() error {
+	if bst.IsNil() {
+		return treeNilError
+	}
+
+	if bst.IsEmpty() {
+		return treeEmptyError
+	}
+	return nil
+}
+
+// measure applies one of the shape measurements to the whole binary search tree, once it is sure there is a shape to measure
+func measure[T, R any](bst *BinarySearchTree[T], measurement func(root *Node[T], children func(*Node[T]) (*Node[T], *Node[T])) R, invalid R) (R, error) {
+	err := bst.checkMeasurable()
+	if err != nil {
+		return invalid, err
+	}
+	return measurement(bst.root, (*Node[T]).children), nil
+}
+
+// Height returns the number of edges on the longest path from the root down to a leaf (a single node tree has height 0)
+// Every node already keeps the height of its subtree (counted in nodes), so this is O(1)
+func (bst *BinarySearchTree[T]) Height() (int, error) {
+	err := bst.checkMeasurable()
+	if err != nil {
+		return invalidCount, err
+	}
+	return bst.root.height - 1, nil
+}
+
+// Diameter returns the number of edges on the longest path between any two nodes of the binary search tree
+func (bst *BinarySearchTree[T]) Diameter() (int, error) {
+	return measure(bst, shape.Diameter[*Node[T]], invalidCount)
+}
+
+// LevelCounts returns the number of nodes on each level of the binary search tree, starting with the root's level
+func (bst *BinarySearchTree[T]) LevelCounts() ([]int, error) {
+	return measure(bst, shape.LevelCounts[*Node[T]], []int(nil))
+}
+
+// MaxWidth returns the largest number of nodes on any single level of the binary search tree
+func (bst *BinarySearchTree[T]) MaxWidth() (int, error) {
+	return measure(bst, shape.MaxWidth[*Node[T]], invalidCount)
+}
+
+// LeafCount returns the number of nodes with no children in the binary search tree
+func (bst *BinarySearchTree[T]) LeafCount() (int, error) {
+	return measure(bst, shape.LeafCount[*Node[T]], invalidCount)
+}
+
+// IsComplete tells you if every level of the binary search tree is full, except possibly the last one, which is filled from the left
+func (bst *BinarySearchTree[T]) IsComplete() (bool, error) {
+	return measure(bst, shape.IsComplete[*Node[T]], false)
+}
+
+// IsFull tells you if every node of the binary search tree has either no children or two children
+func (bst *BinarySearchTree[T]) IsFull() (bool, error) {
+	return measure(bst, shape.IsFull[*Node[T]], false)
+}
+
+// IsPerfect tells you if the binary search tree is full and all of its leaves are on the same level
+func (bst *BinarySearchTree[T]) IsPerfect() (bool, error) {
+	return measure(bst, shape.IsPerfect[*Node[T]], false)
+}
+
+// IsHeightBalanced tells you if the heights of the two subtrees of every node in the binary search tree differ by at most one
+func (bst *BinarySearchTree[T]) IsHeightBalanced() (bool, error) {
+	return measure(bst, shape.IsHeightBalanced[*Node[T]], false)
+}
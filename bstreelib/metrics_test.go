@@ -0,0 +1,159 @@
+package bstreelib
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/pluckynumbat/go-tree/internal/shape"
+)
+
+func TestMetrics(t *testing.T) {
+	tests := []struct {
+		name           string
+		values         []int
+		height         int
+		diameter       int
+		levelCounts    []int
+		maxWidth       int
+		leafCount      int
+		complete       bool
+		full           bool
+		perfect        bool
+		heightBalanced bool
+	}{
+		{"single node", []int{1}, 0, 0, []int{1}, 1, 1, true, true, true, true},
+		{"perfect tree", []int{4, 2, 6, 1, 3, 5, 7}, 2, 4, []int{1, 2, 4}, 4, 4, true, true, true, true},
+		{"complete tree", []int{4, 2, 6, 1}, 2, 3, []int{1, 2, 1}, 2, 2, true, false, false, true},
+		{"full tree", []int{2, 1, 4, 3, 5}, 2, 3, []int{1, 2, 2}, 2, 3, false, true, false, true},
+		{"sorted input", []int{1, 2, 3, 4}, 3, 3, []int{1, 1, 1, 1}, 1, 1, false, false, false, false},
+		{"zig zag", []int{10, 1, 9, 2, 8}, 4, 4, []int{1, 1, 1, 1, 1}, 1, 1, false, false, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bst, err := ConstructFromValues(test.values...)
+			if err != nil {
+				t.Fatalf("ConstructFromValues() failed with error: %v", err)
+			}
+
+			intResults := []struct {
+				method string
+				want   int
+				got    func() (int, error)
+			}{
+				{"Height", test.height, bst.Height},
+				{"Diameter", test.diameter, bst.Diameter},
+				{"MaxWidth", test.maxWidth, bst.MaxWidth},
+				{"LeafCount", test.leafCount, bst.LeafCount},
+			}
+
+			for _, result := range intResults {
+				got, err2 := result.got()
+				if err2 != nil {
+					t.Fatalf("%v() failed with error: %v", result.method, err2)
+				} else if got != result.want {
+					t.Errorf("%v() returned incorrect results, want: %v, got: %v", result.method, result.want, got)
+				}
+			}
+
+			boolResults := []struct {
+				method string
+				want   bool
+				got    func() (bool, error)
+			}{
+				{"IsComplete", test.complete, bst.IsComplete},
+				{"IsFull", test.full, bst.IsFull},
+				{"IsPerfect", test.perfect, bst.IsPerfect},
+				{"IsHeightBalanced", test.heightBalanced, bst.IsHeightBalanced},
+			}
+
+			for _, result := range boolResults {
+				got, err2 := result.got()
+				if err2 != nil {
+					t.Fatalf("%v() failed with error: %v", result.method, err2)
+				} else if got != result.want {
+					t.Errorf("%v() returned incorrect results, want: %v, got: %v", result.method, result.want, got)
+				}
+			}
+
+			levelCounts, err := bst.LevelCounts()
+			if err != nil {
+				t.Fatalf("LevelCounts() failed with error: %v", err)
+			} else if !slices.Equal(levelCounts, test.levelCounts) {
+				t.Errorf("LevelCounts() returned incorrect results, want: %v, got: %v", test.levelCounts, levelCounts)
+			}
+		})
+	}
+}
+
+func TestMetricsErrors(t *testing.T) {
+	var nilTree *BinarySearchTree[int]
+
+	_, err := nilTree.Height()
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("Height() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	_, err = New[int]().Diameter()
+	if !errors.Is(err, treeEmptyError) {
+		t.Errorf("Diameter() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	_, err = New[int]().IsHeightBalanced()
+	if !errors.Is(err, treeEmptyError) {
+		t.Errorf("IsHeightBalanced() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	}
+}
+
+func TestBalancingShape(t *testing.T) {
+	values := rand.New(rand.NewSource(19)).Perm(1000)
+
+	bst, err := ConstructFromValues(values...)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	avl, err := ConstructAVLFromValues(values...)
+	if err != nil {
+		t.Fatalf("ConstructAVLFromValues() failed with error: %v", err)
+	}
+
+	err = bst.BalanceTree()
+	if err != nil {
+		t.Fatalf("BalanceTree() failed with error: %v", err)
+	}
+
+	for name, tree := range map[string]*BinarySearchTree[int]{"BalanceTree": bst, "AVL": avl} {
+		t.Run(name, func(t *testing.T) {
+			balanced, err2 := tree.IsHeightBalanced()
+			if err2 != nil {
+				t.Fatalf("IsHeightBalanced() failed with error: %v", err2)
+			} else if !balanced {
+				t.Errorf("the tree should be height balanced")
+			}
+
+			// the O(1) height kept in the nodes should match a full walk of the tree
+			height, err2 := tree.Height()
+			if err2 != nil {
+				t.Fatalf("Height() failed with error: %v", err2)
+			} else if want := shape.Height(tree.root, (*Node[int]).children); height != want {
+				t.Errorf("Height() returned incorrect results, want: %v, got: %v", want, height)
+			}
+		})
+	}
+
+	// a perfectly balanced tree of 1000 values has 10 levels
+	height, err := bst.Height()
+	if err != nil {
+		t.Fatalf("Height() failed with error: %v", err)
+	} else if height != 9 {
+		t.Errorf("Height() after BalanceTree() returned incorrect results, want: %v, got: %v", 9, height)
+	}
+}
@@ -0,0 +1,118 @@
+package bstreelib
+
+import (
+	"cmp"
+	"fmt"
+)
+
+var repeatedValuesError = fmt.Errorf("the binary search tree holds repeated values, which only the CountDuplicates policy can keep")
+
+// DuplicatePolicy decides what Insert does with a value that is already present in the binary search tree
+type DuplicatePolicy int
+
+const (
+	RejectDuplicates  DuplicatePolicy = iota // return a duplicateElementError, the zero value
+	IgnoreDuplicates                         // keep the value already in the tree, and report no error
+	ReplaceDuplicates                        // overwrite the value already in the tree with the new one (useful when the comparator only looks at part of the value)
+	CountDuplicates                          // keep every occurrence, making the tree a multiset
+)
+
+// DuplicatePolicy's implementation of the fmt.Stringer interface
+func (policy DuplicatePolicy) String() string {
+	switch policy {
+	case RejectDuplicates:
+		return "reject duplicates"
+	case IgnoreDuplicates:
+		return "ignore duplicates"
+	case ReplaceDuplicates:
+		return "replace duplicates"
+	case CountDuplicates:
+		return "count duplicates"
+	}
+	return fmt.Sprintf("unknown duplicate policy (%d)", int(policy))
+}
+
+// SetDuplicatePolicy changes what Insert does with values that are already present in the binary search tree
+// Leaving the CountDuplicates policy fails while the tree still holds repeated values
+func (bst *BinarySearchTree[T]) SetDuplicatePolicy(policy DuplicatePolicy) error {
+	if bst.IsNil() {
+		return treeNilError
+	}
+
+	if policy < RejectDuplicates || policy > CountDuplicates {
+		return fmt.Errorf("cannot set the duplicate policy to an %v", policy)
+	}
+
+	if bst.duplicates == CountDuplicates && policy != CountDuplicates && hasRepeats(bst.root) {
+		return repeatedValuesError
+	}
+
+	bst.duplicates = policy
+	return nil
+}
+
+// hasRepeats tells you if any node in the subtree holds more than one occurrence of its value
+func hasRepeats[T any](node *Node[T]) bool {
+	if node == nil {
+		return false
+	}
+	return node.occurrences > 1 || hasRepeats(node.left) || hasRepeats(node.right)
+}
+
+// insertDuplicate applies the tree's duplicate policy to a value equal to the one in the given node
+func (bst *BinarySearchTree[T]) insertDuplicate(node *Node[T], value T) error {
+	switch bst.duplicates {
+	case IgnoreDuplicates:
+		return nil
+
+	case ReplaceDuplicates:
+		node.data = value
+		return nil
+
+	case CountDuplicates:
+		node.occurrences += 1
+		bst.count += 1
+		bst.retrace(node)
+		return nil
+	}
+
+	return duplicateElementError[T]{value}
+}
+
+// ConstructFromValuesWithPolicy is a helper function to insert all the given values (in the order that they are provided)
+// into a binary search tree which handles repeated values according to the given policy
+func ConstructFromValuesWithPolicy[T cmp.Ordered](policy DuplicatePolicy, values ...T) (*BinarySearchTree[T], error) {
+	bstree := New[T]()
+
+	err := bstree.SetDuplicatePolicy(policy)
+	if err != nil {
+		return nil, fmt.Errorf("construct from values failed with error: %v", err)
+	}
+
+	for _, val := range values {
+		err = bstree.Insert(val)
+		if err != nil {
+			return nil, fmt.Errorf("construct from values failed with error: %v", err)
+		}
+	}
+
+	return bstree, nil
+}
+
+// Occurrences returns the number of times the value is present in the binary search tree,
+// which can only be more than 1 with the CountDuplicates policy
+func (bst *BinarySearchTree[T]) Occurrences(value T) (int, error) {
+	if bst.IsNil() {
+		return invalidCount, treeNilError
+	}
+
+	node, err := bst.findNode(value)
+	if err != nil {
+		return invalidCount, err
+	}
+
+	if node == nil {
+		return 0, nil
+	}
+	return node.occurrences, nil
+}
@@ -0,0 +1,242 @@
+package bstreelib
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestSetDuplicatePolicy(t *testing.T) {
+	var bst *BinarySearchTree[prInt]
+	err := bst.SetDuplicatePolicy(CountDuplicates)
+	if !errors.Is(err, treeNilError) {
+		t.Fatalf("SetDuplicatePolicy() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	}
+
+	bst = &BinarySearchTree[prInt]{}
+	err = bst.SetDuplicatePolicy(DuplicatePolicy(7))
+	if err == nil {
+		t.Fatalf("SetDuplicatePolicy() with an unknown policy should have failed")
+	} else {
+		fmt.Println(err)
+	}
+
+	err = bst.SetDuplicatePolicy(CountDuplicates)
+	if err != nil {
+		t.Fatalf("SetDuplicatePolicy() failed with error: %v", err)
+	}
+
+	for _, val := range []prInt{2, 1, 2} {
+		err = bst.Insert(val)
+		if err != nil {
+			t.Fatalf("Insert() failed with error: %v", err)
+		}
+	}
+
+	// the tree still holds a repeated value
+	err = bst.SetDuplicatePolicy(RejectDuplicates)
+	if !errors.Is(err, repeatedValuesError) {
+		t.Fatalf("SetDuplicatePolicy() should have failed with error: %v, got: %v", repeatedValuesError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	err = bst.Delete(2)
+	if err != nil {
+		t.Fatalf("Delete() failed with error: %v", err)
+	}
+
+	err = bst.SetDuplicatePolicy(RejectDuplicates)
+	if err != nil {
+		t.Fatalf("SetDuplicatePolicy() failed with error: %v", err)
+	}
+
+	err = bst.Insert(2)
+	if !errors.Is(err, duplicateElementError[prInt]{2}) {
+		t.Fatalf("Insert() should have failed with error: %v, got: %v", duplicateElementError[prInt]{2}, err)
+	}
+}
+
+func TestDuplicatePolicies(t *testing.T) {
+	input := []prInt{3, 1, 3, 2, 3}
+
+	tests := []struct {
+		name       string
+		policy     DuplicatePolicy
+		expError   bool
+		wantCnt    int
+		wantSlice  []prInt
+		wantBFS    string
+		wantInOrd  string
+		wantOccurs int
+	}{
+		{"reject", RejectDuplicates, true, 0, nil, "", "", 0},
+		{"ignore", IgnoreDuplicates, false, 3, []prInt{1, 2, 3}, "-(3)--(1)--(2)-", "-(1)--(2)--(3)-", 1},
+		{"replace", ReplaceDuplicates, false, 3, []prInt{1, 2, 3}, "-(3)--(1)--(2)-", "-(1)--(2)--(3)-", 1},
+		{"count", CountDuplicates, false, 5, []prInt{1, 2, 3, 3, 3}, "-(3)--(3)--(3)--(1)--(2)-", "-(1)--(2)--(3)--(3)--(3)-", 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bst, err := ConstructFromValuesWithPolicy(test.policy, input...)
+			if test.expError {
+				if err == nil {
+					t.Fatalf("ConstructFromValuesWithPolicy() should have failed")
+				}
+				fmt.Println(err)
+				return
+			} else if err != nil {
+				t.Fatalf("ConstructFromValuesWithPolicy() failed with error: %v", err)
+			}
+
+			gotCnt, err := bst.Count()
+			if err != nil {
+				t.Fatalf("Count() failed with error: %v", err)
+			} else if gotCnt != test.wantCnt {
+				t.Errorf("Count() returned incorrect results, want: %v, got: %v", test.wantCnt, gotCnt)
+			}
+
+			gotSlice, err := bst.ConstructOrderedSlice()
+			if err != nil {
+				t.Fatalf("ConstructOrderedSlice() failed with error: %v", err)
+			} else if !slices.Equal(gotSlice, test.wantSlice) {
+				t.Errorf("ConstructOrderedSlice() returned incorrect results, want: %v, got: %v", test.wantSlice, gotSlice)
+			}
+
+			if got := slices.Collect(bst.All()); !slices.Equal(got, test.wantSlice) {
+				t.Errorf("All() returned incorrect results, want: %v, got: %v", test.wantSlice, got)
+			}
+
+			gotBFS, err := bst.TraverseBFS()
+			if err != nil {
+				t.Fatalf("TraverseBFS() failed with error: %v", err)
+			} else if gotBFS != test.wantBFS {
+				t.Errorf("TraverseBFS() returned incorrect results, want: %v, got: %v", test.wantBFS, gotBFS)
+			}
+
+			gotInOrd, err := bst.TraverseDFSInOrder()
+			if err != nil {
+				t.Fatalf("TraverseDFSInOrder() failed with error: %v", err)
+			} else if gotInOrd != test.wantInOrd {
+				t.Errorf("TraverseDFSInOrder() returned incorrect results, want: %v, got: %v", test.wantInOrd, gotInOrd)
+			}
+
+			gotOccurs, err := bst.Occurrences(3)
+			if err != nil {
+				t.Fatalf("Occurrences() failed with error: %v", err)
+			} else if gotOccurs != test.wantOccurs {
+				t.Errorf("Occurrences() returned incorrect results, want: %v, got: %v", test.wantOccurs, gotOccurs)
+			}
+
+			checkSubtreeSizes(t, bst)
+		})
+	}
+
+	t.Run("replace keeps the newest value", func(t *testing.T) {
+		type version struct {
+			id, rev int
+		}
+
+		bst := NewWithComparator(func(a, b version) int { return a.id - b.id })
+		err := bst.SetDuplicatePolicy(ReplaceDuplicates)
+		if err != nil {
+			t.Fatalf("SetDuplicatePolicy() failed with error: %v", err)
+		}
+
+		for _, v := range []version{{1, 1}, {2, 1}, {1, 2}, {1, 3}} {
+			err = bst.Insert(v)
+			if err != nil {
+				t.Fatalf("Insert() failed with error: %v", err)
+			}
+		}
+
+		want := []version{{1, 3}, {2, 1}}
+		got, err := bst.ConstructOrderedSlice()
+		if err != nil {
+			t.Fatalf("ConstructOrderedSlice() failed with error: %v", err)
+		} else if !slices.Equal(got, want) {
+			t.Errorf("ConstructOrderedSlice() returned incorrect results, want: %v, got: %v", want, got)
+		}
+	})
+}
+
+func TestMultisetQueries(t *testing.T) {
+	// 1, 2, 2, 5, 5, 5, 8
+	bst, err := ConstructFromValuesWithPolicy[prInt](CountDuplicates, 5, 2, 8, 5, 1, 2, 5)
+	if err != nil {
+		t.Fatalf("ConstructFromValuesWithPolicy() failed with error: %v", err)
+	}
+
+	for k, want := range []prInt{1, 2, 2, 5, 5, 5, 8} {
+		got, err2 := bst.Select(k)
+		if err2 != nil {
+			t.Fatalf("Select() failed with error: %v", err2)
+		} else if got != want {
+			t.Errorf("Select(%v) returned incorrect results, want: %v, got: %v", k, want, got)
+		}
+	}
+
+	ranks := []struct {
+		x    prInt
+		want int
+	}{
+		{1, 0}, {2, 1}, {3, 3}, {5, 3}, {6, 6}, {8, 6}, {9, 7},
+	}
+	for _, rank := range ranks {
+		got, err2 := bst.Rank(rank.x)
+		if err2 != nil {
+			t.Fatalf("Rank() failed with error: %v", err2)
+		} else if got != rank.want {
+			t.Errorf("Rank(%v) returned incorrect results, want: %v, got: %v", rank.x, rank.want, got)
+		}
+	}
+
+	gotCnt, err := bst.CountRange(2, 5, Closed)
+	if err != nil {
+		t.Fatalf("CountRange() failed with error: %v", err)
+	} else if gotCnt != 5 {
+		t.Errorf("CountRange() returned incorrect results, want: %v, got: %v", 5, gotCnt)
+	}
+
+	gotRange := slices.Collect(bst.Range(2, 5, Closed))
+	if want := []prInt{2, 2, 5, 5, 5}; !slices.Equal(gotRange, want) {
+		t.Errorf("Range() returned incorrect results, want: %v, got: %v", want, gotRange)
+	}
+
+	// deleting removes a single occurrence
+	err = bst.Delete(5)
+	if err != nil {
+		t.Fatalf("Delete() failed with error: %v", err)
+	}
+
+	occurs, err := bst.Occurrences(5)
+	if err != nil {
+		t.Fatalf("Occurrences() failed with error: %v", err)
+	} else if occurs != 2 {
+		t.Errorf("Occurrences() after Delete() returned incorrect results, want: %v, got: %v", 2, occurs)
+	}
+
+	// deleting a range removes every occurrence
+	removed, err := bst.DeleteRange(2, 5, Closed)
+	if err != nil {
+		t.Fatalf("DeleteRange() failed with error: %v", err)
+	} else if removed != 4 {
+		t.Errorf("DeleteRange() removed an incorrect number of values, want: %v, got: %v", 4, removed)
+	}
+
+	err = bst.BalanceTree()
+	if err != nil {
+		t.Fatalf("BalanceTree() failed with error: %v", err)
+	}
+
+	got, err := bst.ConstructOrderedSlice()
+	if err != nil {
+		t.Fatalf("ConstructOrderedSlice() failed with error: %v", err)
+	} else if want := []prInt{1, 8}; !slices.Equal(got, want) {
+		t.Errorf("ConstructOrderedSlice() returned incorrect results, want: %v, got: %v", want, got)
+	}
+
+	checkSubtreeSizes(t, bst)
+	checkParentPointers(t, bst)
+}
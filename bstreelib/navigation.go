@@ -0,0 +1,164 @@
+package bstreelib
+
+// Next returns a pointer to the node holding the next larger value in the tree (the in-order successor),
+// or nil if this node holds the largest value
+func (node *Node[T]) Next() (*Node[T], error) {
+	if node == nil {
+		return nil, nodeNilError
+	}
+
+	if node.right != nil {
+		return leftmost(node.right), nil
+	}
+
+	// climb up till we come from a left subtree
+	runner := node
+	for runner.parent != nil && runner.parent.right == runner {
+		runner = runner.parent
+	}
+	return runner.parent, nil
+}
+
+// Prev returns a pointer to the node holding the next smaller value in the tree (the in-order predecessor),
+// or nil if this node holds the smallest value
+func (node *Node[T]) Prev() (*Node[T], error) {
+	if node == nil {
+		return nil, nodeNilError
+	}
+
+	if node.left != nil {
+		return rightmost(node.left), nil
+	}
+
+	// climb up till we come from a right subtree
+	runner := node
+	for runner.parent != nil && runner.parent.left == runner {
+		runner = runner.parent
+	}
+	return runner.parent, nil
+}
+
+// leftmost returns the node with the smallest value in the subtree rooted at the given (non nil) node
+func leftmost[T any](node *Node[T]) *Node[T] {
+	for node.left != nil {
+		node = node.left
+	}
+	return node
+}
+
+// rightmost returns the node with the largest value in the subtree rooted at the given (non nil) node
+func rightmost[T any](node *Node[T]) *Node[T] {
+	for node.right != nil {
+		node = node.right
+	}
+	return node
+}
+
+// Min returns the smallest value in the binary search tree
+func (bst *BinarySearchTree[T]) Min() (T, bool, error) {
+	if bst.IsNil() {
+		return *new(T), false, treeNilError
+	}
+
+	if bst.IsEmpty() {
+		return *new(T), false, treeEmptyError
+	}
+
+	return leftmost(bst.root).data, true, nil
+}
+
+// Max returns the largest value in the binary search tree
+func (bst *BinarySearchTree[T]) Max() (T, bool, error) {
+	if bst.IsNil() {
+		return *new(T), false, treeNilError
+	}
+
+	if bst.IsEmpty() {
+		return *new(T), false, treeEmptyError
+	}
+
+	return rightmost(bst.root).data, true, nil
+}
+
+// Floor returns the largest value in the binary search tree that is less than or equal to x,
+// the boolean is false if there is no such value
+func (bst *BinarySearchTree[T]) Floor(x T) (T, bool, error) {
+	return bst.closestValue(x, true, true)
+}
+
+// Ceiling returns the smallest value in the binary search tree that is greater than or equal to x,
+// the boolean is false if there is no such value
+func (bst *BinarySearchTree[T]) Ceiling(x T) (T, bool, error) {
+	return bst.closestValue(x, false, true)
+}
+
+// Predecessor returns the largest value in the binary search tree that is strictly less than x,
+// the boolean is false if there is no such value. x itself does not need to be present in the tree
+func (bst *BinarySearchTree[T]) Predecessor(x T) (T, bool, error) {
+	return bst.closestValue(x, true, false)
+}
+
+// Successor returns the smallest value in the binary search tree that is strictly greater than x,
+// the boolean is false if there is no such value. x itself does not need to be present in the tree
+func (bst *BinarySearchTree[T]) Successor(x T) (T, bool, error) {
+	return bst.closestValue(x, false, false)
+}
+
+// closestValue is the common implementation of Floor, Ceiling, Predecessor and Successor
+func (bst *BinarySearchTree[T]) closestValue(x T, below, inclusive bool) (T, bool, error) {
+	if bst.IsNil() {
+		return *new(T), false, treeNilError
+	}
+
+	if bst.IsEmpty() {
+		return *new(T), false, treeEmptyError
+	}
+
+	node, err := bst.closestNode(x, below, inclusive)
+	if err != nil {
+		return *new(T), false, err
+	}
+
+	if node == nil {
+		return *new(T), false, nil
+	}
+	return node.data, true, nil
+}
+
+// closestNode walks down from the root looking for the node closest to x, on the side of x given by below,
+// and returns nil if there is no such node
+func (bst *BinarySearchTree[T]) closestNode(x T, below, inclusive bool) (*Node[T], error) {
+	compare, err := bst.comparator()
+	if err != nil {
+		return nil, err
+	}
+
+	var best *Node[T]
+	runner := bst.root
+
+	for runner != nil {
+		result := compare(x, runner.data)
+
+		if result == 0 && inclusive {
+			return runner, nil
+		}
+
+		if below {
+			if result > 0 { // this node is a candidate, look for a larger one in the right subtree
+				best = runner
+				runner = runner.right
+			} else {
+				runner = runner.left
+			}
+		} else {
+			if result < 0 { // this node is a candidate, look for a smaller one in the left subtree
+				best = runner
+				runner = runner.left
+			} else {
+				runner = runner.right
+			}
+		}
+	}
+
+	return best, nil
+}
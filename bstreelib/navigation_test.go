@@ -0,0 +1,192 @@
+package bstreelib
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMinMax(t *testing.T) {
+	var bst *BinarySearchTree[prInt]
+
+	_, _, err := bst.Min()
+	if !errors.Is(err, treeNilError) {
+		t.Fatalf("Min() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	}
+
+	bst = &BinarySearchTree[prInt]{}
+	_, _, err = bst.Max()
+	if !errors.Is(err, treeEmptyError) {
+		t.Fatalf("Max() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	}
+
+	tests := []struct {
+		name    string
+		input   []prInt
+		wantMin prInt
+		wantMax prInt
+	}{
+		{"1 element tree", []prInt{3}, 3, 3},
+		{"left leaning tree", []prInt{3, 2, 1}, 1, 3},
+		{"right leaning tree", []prInt{1, 2, 3}, 1, 3},
+		{"zig zag tree", []prInt{10, 2, 8, 4, 6}, 2, 10},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bst, err2 := ConstructFromValues(test.input...)
+			if err2 != nil {
+				t.Fatalf("ConstructFromValues() failed with error: %v", err2)
+			}
+
+			gotMin, found, err2 := bst.Min()
+			if err2 != nil {
+				t.Fatalf("Min() failed with error: %v", err2)
+			} else if !found || gotMin != test.wantMin {
+				t.Errorf("Min() returned incorrect results, want: %v, got: %v (found: %v)", test.wantMin, gotMin, found)
+			}
+
+			gotMax, found, err2 := bst.Max()
+			if err2 != nil {
+				t.Fatalf("Max() failed with error: %v", err2)
+			} else if !found || gotMax != test.wantMax {
+				t.Errorf("Max() returned incorrect results, want: %v, got: %v (found: %v)", test.wantMax, gotMax, found)
+			}
+		})
+	}
+}
+
+func TestFloorCeilingPredecessorSuccessor(t *testing.T) {
+	var bst *BinarySearchTree[prInt]
+
+	queries := []struct {
+		name  string
+		query func(x prInt) (prInt, bool, error)
+	}{
+		{"Floor", bst.Floor},
+		{"Ceiling", bst.Ceiling},
+		{"Predecessor", bst.Predecessor},
+		{"Successor", bst.Successor},
+	}
+	for _, query := range queries {
+		_, _, err := query.query(1)
+		if !errors.Is(err, treeNilError) {
+			t.Errorf("%v() on a nil tree should have failed with error: %v, got: %v", query.name, treeNilError, err)
+		} else {
+			fmt.Println(err)
+		}
+	}
+
+	bst, err := ConstructFromValues[prInt](20, 10, 30, 5, 15, 25, 35)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	// -1 marks that no value should be found
+	tests := []struct {
+		name      string
+		x         prInt
+		wantFloor prInt
+		wantCeil  prInt
+		wantPred  prInt
+		wantSucc  prInt
+	}{
+		{"below the minimum", 1, -1, 5, -1, 5},
+		{"the minimum", 5, 5, 5, -1, 10},
+		{"between leaf and parent", 7, 5, 10, 5, 10},
+		{"inner node", 10, 10, 10, 5, 15},
+		{"between left subtree and root", 17, 15, 20, 15, 20},
+		{"the root", 20, 20, 20, 15, 25},
+		{"between root and right subtree", 22, 20, 25, 20, 25},
+		{"leaf", 25, 25, 25, 20, 30},
+		{"the maximum", 35, 35, 35, 30, -1},
+		{"above the maximum", 40, 35, -1, 35, -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := []struct {
+				name  string
+				query func(x prInt) (prInt, bool, error)
+				want  prInt
+			}{
+				{"Floor", bst.Floor, test.wantFloor},
+				{"Ceiling", bst.Ceiling, test.wantCeil},
+				{"Predecessor", bst.Predecessor, test.wantPred},
+				{"Successor", bst.Successor, test.wantSucc},
+			}
+
+			for _, result := range results {
+				got, found, err2 := result.query(test.x)
+				if err2 != nil {
+					t.Fatalf("%v() failed with error: %v", result.name, err2)
+				}
+
+				if !found {
+					got = -1
+				}
+				if got != result.want {
+					t.Errorf("%v(%v) returned incorrect results, want: %v, got: %v", result.name, test.x, result.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestNodeNextPrev(t *testing.T) {
+	var nilNode *Node[prInt]
+
+	_, err := nilNode.Next()
+	if !errors.Is(err, nodeNilError) {
+		t.Errorf("Next() on a nil node should have failed with error: %v, got: %v", nodeNilError, err)
+	}
+
+	_, err = nilNode.Prev()
+	if !errors.Is(err, nodeNilError) {
+		t.Errorf("Prev() on a nil node should have failed with error: %v, got: %v", nodeNilError, err)
+	}
+
+	tests := []struct {
+		name  string
+		input []prInt
+		want  string
+	}{
+		{"1 element tree", []prInt{1}, "-(1)-"},
+		{"balanced tree", []prInt{4, 2, 6, 1, 3, 5, 7}, "-(1)--(2)--(3)--(4)--(5)--(6)--(7)-"},
+		{"left leaning tree", []prInt{5, 4, 3, 2, 1}, "-(1)--(2)--(3)--(4)--(5)-"},
+		{"zig zag tree", []prInt{10, 2, 8, 4, 6}, "-(2)--(4)--(6)--(8)--(10)-"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bst, err2 := ConstructFromValues(test.input...)
+			if err2 != nil {
+				t.Fatalf("ConstructFromValues() failed with error: %v", err2)
+			}
+
+			// step forward from the smallest node
+			got := ""
+			for node := leftmost(bst.root); node != nil; node, err2 = node.Next() {
+				if err2 != nil {
+					t.Fatalf("Next() failed with error: %v", err2)
+				}
+				got += fmt.Sprintf("-(%v)-", node)
+			}
+			if got != test.want {
+				t.Errorf("stepping with Next() gave incorrect results, want: %v, got: %v", test.want, got)
+			}
+
+			// step backward from the largest node
+			got = ""
+			for node := rightmost(bst.root); node != nil; node, err2 = node.Prev() {
+				if err2 != nil {
+					t.Fatalf("Prev() failed with error: %v", err2)
+				}
+				got = fmt.Sprintf("-(%v)-", node) + got
+			}
+			if got != test.want {
+				t.Errorf("stepping with Prev() gave incorrect results, want: %v, got: %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package bstreelib
+
+import "fmt"
+
+var indexOutOfRangeError = fmt.Errorf("the index is out of range")
+
+// Select returns the k-th smallest value in the binary search tree, where k starts at 0 for the minimum
+// Repeated values take up one position per occurrence
+func (bst *BinarySearchTree[T]) Select(k int) (T, error) {
+	if bst.IsNil() {
+		return *new(T), treeNilError
+	}
+
+	if bst.IsEmpty() {
+		return *new(T), treeEmptyError
+	}
+
+	if k < 0 || k >= bst.root.size {
+		return *new(T), indexOutOfRangeError
+	}
+
+	runner := bst.root
+	for runner != nil {
+		leftSize := runner.left.subtreeSize()
+
+		switch {
+		case k < leftSize:
+			runner = runner.left
+
+		case k < leftSize+runner.occurrences:
+			return runner.data, nil
+
+		default:
+			k -= leftSize + runner.occurrences
+			runner = runner.right
+		}
+	}
+
+	// unreachable as long as the subtree sizes are correct
+	return *new(T), indexOutOfRangeError
+}
+
+// Rank returns the number of values in the binary search tree that are strictly less than x
+// x itself does not need to be present in the tree
+func (bst *BinarySearchTree[T]) Rank(x T) (int, error) {
+	if bst.IsNil() {
+		return invalidCount, treeNilError
+	}
+
+	return bst.countBelow(x, false)
+}
+
+// countBelow returns the number of values in the tree that are less than x (or less than or equal to x if inclusive)
+func (bst *BinarySearchTree[T]) countBelow(x T, inclusive bool) (int, error) {
+	compare, err := bst.comparator()
+	if err != nil {
+		return invalidCount, err
+	}
+
+	count := 0
+	runner := bst.root
+
+	for runner != nil {
+		result := compare(x, runner.data)
+
+		if result > 0 || (result == 0 && inclusive) {
+			// this node and its left subtree are all below x
+			count += runner.left.subtreeSize() + runner.occurrences
+			runner = runner.right
+		} else {
+			runner = runner.left
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,187 @@
+package bstreelib
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// checkSubtreeSizes walks the whole tree and reports any node whose size does not match its subtree
+func checkSubtreeSizes[T any](t *testing.T, bst *BinarySearchTree[T]) {
+	t.Helper()
+
+	var recurse func(node *Node[T]) int
+	recurse = func(node *Node[T]) int {
+		if node == nil {
+			return 0
+		}
+		want := node.occurrences + recurse(node.left) + recurse(node.right)
+		if node.size != want {
+			t.Errorf("incorrect size for node %v, want: %v, got: %v", node, want, node.size)
+		}
+		return want
+	}
+
+	total := recurse(bst.root)
+	if total != bst.count {
+		t.Errorf("the tree count does not match the number of nodes, want: %v, got: %v", total, bst.count)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	var bst *BinarySearchTree[prInt]
+	_, err := bst.Select(0)
+	if !errors.Is(err, treeNilError) {
+		t.Fatalf("Select() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	}
+
+	bst = &BinarySearchTree[prInt]{}
+	_, err = bst.Select(0)
+	if !errors.Is(err, treeEmptyError) {
+		t.Fatalf("Select() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	}
+
+	bst, err = ConstructFromValues[prInt](50, 30, 70, 20, 40, 60, 80, 35)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		k        int
+		want     prInt
+		expError error
+	}{
+		{"negative index", -1, 0, indexOutOfRangeError},
+		{"minimum", 0, 20, nil},
+		{"left subtree", 2, 35, nil},
+		{"root", 4, 50, nil},
+		{"right subtree", 5, 60, nil},
+		{"maximum", 7, 80, nil},
+		{"index past the end", 8, 0, indexOutOfRangeError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err2 := bst.Select(test.k)
+			if err2 != nil && !errors.Is(err2, test.expError) {
+				t.Fatalf("Select() failed with unexpected error: %v", err2)
+			} else if err2 != nil {
+				fmt.Println(err2)
+			} else if got != test.want {
+				t.Errorf("Select(%v) returned incorrect results, want: %v, got: %v", test.k, test.want, got)
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	var bst *BinarySearchTree[prInt]
+	_, err := bst.Rank(0)
+	if !errors.Is(err, treeNilError) {
+		t.Fatalf("Rank() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	}
+
+	bst = &BinarySearchTree[prInt]{}
+	got, err := bst.Rank(5)
+	if err != nil {
+		t.Fatalf("Rank() failed with error: %v", err)
+	} else if got != 0 {
+		t.Errorf("Rank() on an empty tree returned incorrect results, want: %v, got: %v", 0, got)
+	}
+
+	bst, err = ConstructFromValues[prInt](50, 30, 70, 20, 40, 60, 80, 35)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		x    prInt
+		want int
+	}{
+		{"below the minimum", 10, 0},
+		{"the minimum", 20, 0},
+		{"absent value", 36, 3},
+		{"present value", 40, 3},
+		{"the root", 50, 4},
+		{"the maximum", 80, 7},
+		{"above the maximum", 90, 8},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err2 := bst.Rank(test.x)
+			if err2 != nil {
+				t.Fatalf("Rank() failed with error: %v", err2)
+			} else if got != test.want {
+				t.Errorf("Rank(%v) returned incorrect results, want: %v, got: %v", test.x, test.want, got)
+			}
+		})
+	}
+}
+
+func TestSubtreeSizesThroughUpdates(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 11))
+	bst := New[int]()
+	present := make([]int, 0, 200)
+
+	for step := 0; step < 1000; step++ {
+		val := rng.IntN(200)
+		idx, found := slices.BinarySearch(present, val)
+
+		if found {
+			err := bst.Delete(val)
+			if err != nil {
+				t.Fatalf("Delete() failed with error: %v", err)
+			}
+			present = slices.Delete(present, idx, idx+1)
+		} else {
+			err := bst.Insert(val)
+			if err != nil {
+				t.Fatalf("Insert() failed with error: %v", err)
+			}
+			present = slices.Insert(present, idx, val)
+		}
+
+		if step%100 == 0 {
+			err := bst.BalanceTree()
+			if err != nil {
+				t.Fatalf("BalanceTree() failed with error: %v", err)
+			}
+		}
+	}
+
+	checkSubtreeSizes(t, bst)
+
+	for k, want := range present {
+		got, err := bst.Select(k)
+		if err != nil {
+			t.Fatalf("Select() failed with error: %v", err)
+		} else if got != want {
+			t.Errorf("Select(%v) returned incorrect results, want: %v, got: %v", k, want, got)
+		}
+
+		rank, err := bst.Rank(want)
+		if err != nil {
+			t.Fatalf("Rank() failed with error: %v", err)
+		} else if rank != k {
+			t.Errorf("Rank(%v) returned incorrect results, want: %v, got: %v", want, k, rank)
+		}
+	}
+
+	deleted, err := bst.DeleteRange(50, 150, HalfOpen)
+	if err != nil {
+		t.Fatalf("DeleteRange() failed with error: %v", err)
+	}
+
+	lo, _ := slices.BinarySearch(present, 50)
+	hi, _ := slices.BinarySearch(present, 150)
+	if deleted != hi-lo {
+		t.Errorf("DeleteRange() removed an incorrect number of values, want: %v, got: %v", hi-lo, deleted)
+	}
+
+	checkSubtreeSizes(t, bst)
+}
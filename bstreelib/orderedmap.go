@@ -0,0 +1,198 @@
+package bstreelib
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/pluckynumbat/go-tree/internal/ordering"
+	"iter"
+)
+
+var mapNilError = fmt.Errorf("the ordered map is nil")
+
+// entry is a key value pair stored in the nodes of an ordered map's tree, the tree orders entries by key alone
+type entry[K, V any] struct {
+	key   K
+	value V
+}
+
+// entry's implementation of the fmt.Stringer interface
+func (e entry[K, V]) String() string {
+	return fmt.Sprintf("%v: %v", e.key, e.value)
+}
+
+// OrderedMap is a sorted dictionary built on top of a self balancing (AVL) binary search tree,
+// so all its operations run in O(log n) and its iterators visit the keys in ascending order
+// The zero value is ready to use for key types whose underlying type is ordered (integers, floats and strings),
+// other key types need a map created using NewOrderedMapWithComparator
+type OrderedMap[K, V any] struct {
+	tree *BinarySearchTree[entry[K, V]]
+}
+
+// NewOrderedMap returns an empty ordered map that orders its keys using cmp.Compare
+func NewOrderedMap[K cmp.Ordered, V any]() *OrderedMap[K, V] {
+	return NewOrderedMapWithComparator[K, V](cmp.Compare[K])
+}
+
+// NewOrderedMapWithComparator returns an empty ordered map that orders its keys using the given comparator
+func NewOrderedMapWithComparator[K, V any](compare func(a, b K) int) *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{tree: newEntryTree[K, V](compare)}
+}
+
+// newEntryTree returns an empty self balancing tree of entries, ordered by their keys using the given comparator
+func newEntryTree[K, V any](compare func(a, b K) int) *BinarySearchTree[entry[K, V]] {
+	return NewAVLWithComparator(func(a, b entry[K, V]) int {
+		return compare(a.key, b.key)
+	})
+}
+
+// IsNil tells you if the pointer to the ordered map is nil
+func (om *OrderedMap[K, V]) IsNil() bool {
+	return om == nil
+}
+
+// entries returns the tree holding the map's entries, which is nil for a zero value map that nothing was put into yet
+// It never creates the tree, so reading from a map does not write to it
+func (om *OrderedMap[K, V]) entries() (*BinarySearchTree[entry[K, V]], error) {
+	if om.IsNil() {
+		return nil, mapNilError
+	}
+
+	if om.tree == nil && ordering.Comparator[K]() == nil {
+		return nil, noComparatorError
+	}
+	return om.tree, nil
+}
+
+// growEntries returns the tree holding the map's entries, creating it on the first write to a zero value map
+func (om *OrderedMap[K, V]) growEntries() (*BinarySearchTree[entry[K, V]], error) {
+	if om.IsNil() {
+		return nil, mapNilError
+	}
+
+	if om.tree == nil {
+		compare := ordering.Comparator[K]()
+		if compare == nil {
+			return nil, noComparatorError
+		}
+		om.tree = newEntryTree[K, V](compare)
+	}
+	return om.tree, nil
+}
+
+// find returns the node holding the entry for the given key, or nil if the key is not present in the map
+func (om *OrderedMap[K, V]) find(key K) (*Node[entry[K, V]], error) {
+	tree, err := om.entries()
+	if err != nil || tree == nil {
+		return nil, err
+	}
+	return tree.findNode(entry[K, V]{key: key})
+}
+
+// Len returns the number of keys in the ordered map
+func (om *OrderedMap[K, V]) Len() int {
+	if om.IsNil() || om.tree == nil {
+		return 0
+	}
+	return om.tree.count
+}
+
+// Put associates the value with the key, replacing the value already associated with the key if there is one
+func (om *OrderedMap[K, V]) Put(key K, value V) error {
+	tree, err := om.growEntries()
+	if err != nil {
+		return err
+	}
+
+	node, err := tree.findNode(entry[K, V]{key: key})
+	if err != nil {
+		return err
+	}
+
+	if node != nil {
+		node.data.value = value
+		return nil
+	}
+
+	return tree.Insert(entry[K, V]{key, value})
+}
+
+// Get returns the value associated with the key, the boolean is false if the key is not present in the map
+func (om *OrderedMap[K, V]) Get(key K) (V, bool, error) {
+	node, err := om.find(key)
+	if err != nil {
+		return *new(V), false, err
+	}
+
+	if node == nil {
+		return *new(V), false, nil
+	}
+	return node.data.value, true, nil
+}
+
+// Has tells you whether the key is present in the ordered map
+func (om *OrderedMap[K, V]) Has(key K) (bool, error) {
+	node, err := om.find(key)
+	if err != nil {
+		return false, err
+	}
+	return node != nil, nil
+}
+
+// Delete removes the key (and its value) from the ordered map, like the built-in delete it does nothing if the key is not present
+func (om *OrderedMap[K, V]) Delete(key K) error {
+	node, err := om.find(key)
+	if err != nil || node == nil {
+		return err
+	}
+
+	return om.tree.Delete(node.data)
+}
+
+// Insert adds the key value pairs from the sequence to the ordered map, like maps.Insert
+// Values from the sequence replace those already associated with the same keys
+func (om *OrderedMap[K, V]) Insert(seq iter.Seq2[K, V]) error {
+	for key, value := range seq {
+		err := om.Put(key, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// All returns an iterator over the key value pairs of the ordered map, in ascending order of keys
+func (om *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if om.IsNil() {
+			return
+		}
+
+		for e := range om.tree.InOrder() {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys of the ordered map, in ascending order
+func (om *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range om.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the ordered map, in ascending order of their keys
+func (om *OrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range om.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
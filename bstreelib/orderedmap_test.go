@@ -0,0 +1,252 @@
+package bstreelib
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestOrderedMapNil(t *testing.T) {
+	var om *OrderedMap[string, int]
+
+	if om.Len() != 0 {
+		t.Errorf("Len() on a nil map returned incorrect results, want: %v, got: %v", 0, om.Len())
+	}
+
+	err := om.Put("a", 1)
+	if !errors.Is(err, mapNilError) {
+		t.Errorf("Put() on a nil map should have failed with error: %v, got: %v", mapNilError, err)
+	}
+
+	_, _, err = om.Get("a")
+	if !errors.Is(err, mapNilError) {
+		t.Errorf("Get() on a nil map should have failed with error: %v, got: %v", mapNilError, err)
+	}
+
+	_, err = om.Has("a")
+	if !errors.Is(err, mapNilError) {
+		t.Errorf("Has() on a nil map should have failed with error: %v, got: %v", mapNilError, err)
+	}
+
+	err = om.Delete("a")
+	if !errors.Is(err, mapNilError) {
+		t.Errorf("Delete() on a nil map should have failed with error: %v, got: %v", mapNilError, err)
+	}
+
+	if got := slices.Collect(om.Keys()); len(got) != 0 {
+		t.Errorf("Keys() on a nil map should not yield any keys, got: %v", got)
+	}
+}
+
+func TestOrderedMapPutGet(t *testing.T) {
+	emptyMaps := []struct {
+		name string
+		om   *OrderedMap[string, int]
+	}{
+		{"zero value map", &OrderedMap[string, int]{}},
+		{"map from constructor", NewOrderedMap[string, int]()},
+	}
+
+	for _, m := range emptyMaps {
+		t.Run(m.name, func(t *testing.T) {
+			om := m.om
+
+			_, found, err := om.Get("a")
+			if err != nil {
+				t.Fatalf("Get() on an empty map failed with error: %v", err)
+			} else if found {
+				t.Errorf("Get() on an empty map should not find any key")
+			}
+
+			for i, key := range []string{"m", "c", "x", "a", "e", "z"} {
+				err = om.Put(key, i)
+				if err != nil {
+					t.Fatalf("Put() failed with error: %v", err)
+				}
+			}
+
+			// replace an existing value
+			err = om.Put("x", 100)
+			if err != nil {
+				t.Fatalf("Put() failed with error: %v", err)
+			}
+
+			if om.Len() != 6 {
+				t.Errorf("Len() returned incorrect results, want: %v, got: %v", 6, om.Len())
+			}
+
+			tests := []struct {
+				key       string
+				wantVal   int
+				wantFound bool
+			}{
+				{"m", 0, true},
+				{"x", 100, true},
+				{"z", 5, true},
+				{"b", 0, false},
+			}
+
+			for _, test := range tests {
+				got, found, err2 := om.Get(test.key)
+				if err2 != nil {
+					t.Fatalf("Get() failed with error: %v", err2)
+				} else if got != test.wantVal || found != test.wantFound {
+					t.Errorf("Get(%v) returned incorrect results, want: %v %v, got: %v %v", test.key, test.wantVal, test.wantFound, got, found)
+				}
+
+				has, err2 := om.Has(test.key)
+				if err2 != nil {
+					t.Fatalf("Has() failed with error: %v", err2)
+				} else if has != test.wantFound {
+					t.Errorf("Has(%v) returned incorrect results, want: %v, got: %v", test.key, test.wantFound, has)
+				}
+			}
+
+			wantKeys := []string{"a", "c", "e", "m", "x", "z"}
+			if got := slices.Collect(om.Keys()); !slices.Equal(got, wantKeys) {
+				t.Errorf("Keys() returned incorrect results, want: %v, got: %v", wantKeys, got)
+			}
+
+			wantVals := []int{3, 1, 4, 0, 100, 5}
+			if got := slices.Collect(om.Values()); !slices.Equal(got, wantVals) {
+				t.Errorf("Values() returned incorrect results, want: %v, got: %v", wantVals, got)
+			}
+		})
+	}
+
+	t.Run("zero value map reads do not write to it", func(t *testing.T) {
+		om := &OrderedMap[string, int]{}
+
+		// the tree only gets created by the first write, so concurrent readers don't race
+		wg := sync.WaitGroup{}
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, found, err := om.Get("a")
+				if err != nil || found {
+					t.Errorf("Get() on an empty map returned incorrect results, found: %v (error: %v)", found, err)
+				}
+
+				has, err := om.Has("a")
+				if err != nil || has {
+					t.Errorf("Has() on an empty map returned incorrect results, got: %v (error: %v)", has, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if om.tree != nil {
+			t.Errorf("reading from a zero value map should not have created its tree")
+		}
+	})
+
+	t.Run("zero value map without a natural key ordering", func(t *testing.T) {
+		om := &OrderedMap[[2]int, string]{}
+		err := om.Put([2]int{1, 2}, "a")
+		if !errors.Is(err, noComparatorError) {
+			t.Errorf("Put() should have failed with error: %v, got: %v", noComparatorError, err)
+		}
+
+		_, _, err = om.Get([2]int{1, 2})
+		if !errors.Is(err, noComparatorError) {
+			t.Errorf("Get() should have failed with error: %v, got: %v", noComparatorError, err)
+		}
+	})
+
+	t.Run("custom key comparator", func(t *testing.T) {
+		om := NewOrderedMapWithComparator[string, int](func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		})
+
+		for key, val := range map[string]int{"b": 2, "A": 1, "C": 3} {
+			err := om.Put(key, val)
+			if err != nil {
+				t.Fatalf("Put() failed with error: %v", err)
+			}
+		}
+
+		got, found, err := om.Get("c")
+		if err != nil {
+			t.Fatalf("Get() failed with error: %v", err)
+		} else if !found || got != 3 {
+			t.Errorf("Get() returned incorrect results, want: %v, got: %v", 3, got)
+		}
+
+		wantKeys := []string{"A", "b", "C"}
+		if gotKeys := slices.Collect(om.Keys()); !slices.Equal(gotKeys, wantKeys) {
+			t.Errorf("Keys() returned incorrect results, want: %v, got: %v", wantKeys, gotKeys)
+		}
+	})
+}
+
+func TestOrderedMapDelete(t *testing.T) {
+	om := NewOrderedMap[int, string]()
+	err := om.Insert(maps.All(map[int]string{1: "one", 2: "two", 3: "three", 4: "four"}))
+	if err != nil {
+		t.Fatalf("Insert() failed with error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		key      int
+		wantKeys []int
+	}{
+		{"absent key", 5, []int{1, 2, 3, 4}},
+		{"present key", 2, []int{1, 3, 4}},
+		{"same key again", 2, []int{1, 3, 4}},
+		{"smallest key", 1, []int{3, 4}},
+		{"largest key", 4, []int{3}},
+		{"last key", 3, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err2 := om.Delete(test.key)
+			if err2 != nil {
+				t.Fatalf("Delete() failed with error: %v", err2)
+			}
+
+			got := slices.Collect(om.Keys())
+			if !slices.Equal(got, test.wantKeys) {
+				t.Errorf("Delete() gave incorrect results, want: %v, got: %v", test.wantKeys, got)
+			}
+
+			if om.Len() != len(test.wantKeys) {
+				t.Errorf("Len() returned incorrect results, want: %v, got: %v", len(test.wantKeys), om.Len())
+			}
+		})
+	}
+}
+
+func TestOrderedMapAll(t *testing.T) {
+	om := NewOrderedMap[int, string]()
+	err := om.Insert(maps.All(map[int]string{3: "c", 1: "a", 2: "b"}))
+	if err != nil {
+		t.Fatalf("Insert() failed with error: %v", err)
+	}
+
+	var keys []int
+	var values []string
+	for key, value := range om.All() {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	if !slices.Equal(keys, []int{1, 2, 3}) || !slices.Equal(values, []string{"a", "b", "c"}) {
+		t.Errorf("All() returned incorrect results, got keys: %v, values: %v", keys, values)
+	}
+
+	// the iterators work with the standard library
+	collected := maps.Collect(om.All())
+	if len(collected) != 3 || collected[2] != "b" {
+		t.Errorf("maps.Collect() of All() returned incorrect results, got: %v", collected)
+	}
+
+	for range om.All() {
+		break // breaking out early should not panic
+	}
+}
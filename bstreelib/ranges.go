@@ -0,0 +1,185 @@
+package bstreelib
+
+import "iter"
+
+// Bounds tells a range query whether its lower and upper bounds are part of the range
+type Bounds int
+
+const (
+	HalfOpen Bounds = iota // [lo, hi), the zero value
+	Closed                 // [lo, hi]
+	Open                   // (lo, hi)
+	LeftOpen               // (lo, hi]
+)
+
+// Bounds' implementation of the fmt.Stringer interface
+func (bounds Bounds) String() string {
+	switch bounds {
+	case HalfOpen:
+		return "[lo, hi)"
+	case Closed:
+		return "[lo, hi]"
+	case Open:
+		return "(lo, hi)"
+	case LeftOpen:
+		return "(lo, hi]"
+	}
+	return "unknown bounds"
+}
+
+func (bounds Bounds) includesLow() bool {
+	return bounds == HalfOpen || bounds == Closed
+}
+
+func (bounds Bounds) includesHigh() bool {
+	return bounds == Closed || bounds == LeftOpen
+}
+
+// valueRange holds everything needed to check whether a value lies within a range
+type valueRange[T any] struct {
+	lo, hi  T
+	bounds  Bounds
+	compare func(a, b T) int
+}
+
+// aboveLow tells you if the value lies on the correct side of the lower bound
+func (r valueRange[T]) aboveLow(val T) bool {
+	result := r.compare(val, r.lo)
+	return result > 0 || (result == 0 && r.bounds.includesLow())
+}
+
+// belowHigh tells you if the value lies on the correct side of the upper bound
+func (r valueRange[T]) belowHigh(val T) bool {
+	result := r.compare(val, r.hi)
+	return result < 0 || (result == 0 && r.bounds.includesHigh())
+}
+
+// walk visits the values of the subtree that lie within the range in ascending order (once per occurrence),
+// skipping any subtree that lies entirely outside it. It returns false if the visit function asked to stop
+func (r valueRange[T]) walk(node *Node[T], visit func(T) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	aboveLow, belowHigh := r.aboveLow(node.data), r.belowHigh(node.data)
+
+	// the left subtree only holds smaller values, so it is worth visiting only if this node is above the lower bound
+	if aboveLow && !r.walk(node.left, visit) {
+		return false
+	}
+
+	if aboveLow && belowHigh && !node.yieldOccurrences(visit) {
+		return false
+	}
+
+	// the right subtree only holds larger values, so it is worth visiting only if this node is below the upper bound
+	if belowHigh && !r.walk(node.right, visit) {
+		return false
+	}
+
+	return true
+}
+
+// walkNodes visits the nodes of the subtree whose values lie within the range in ascending order
+func (r valueRange[T]) walkNodes(node *Node[T], visit func(*Node[T])) {
+	if node == nil {
+		return
+	}
+
+	aboveLow, belowHigh := r.aboveLow(node.data), r.belowHigh(node.data)
+
+	if aboveLow {
+		r.walkNodes(node.left, visit)
+	}
+
+	if aboveLow && belowHigh {
+		visit(node)
+	}
+
+	if belowHigh {
+		r.walkNodes(node.right, visit)
+	}
+}
+
+// newValueRange builds the range for a query on the binary search tree
+func (bst *BinarySearchTree[T]) newValueRange(lo, hi T, bounds Bounds) (valueRange[T], error) {
+	compare, err := bst.comparator()
+	if err != nil {
+		return valueRange[T]{}, err
+	}
+	return valueRange[T]{lo, hi, bounds, compare}, nil
+}
+
+// Range returns an iterator over the values of the binary search tree that lie between lo and hi, in ascending order
+// The bounds decide whether lo and hi themselves are part of the range, the zero value (HalfOpen) gives [lo, hi)
+func (bst *BinarySearchTree[T]) Range(lo, hi T, bounds Bounds) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if bst.IsEmpty() {
+			return
+		}
+
+		r, err := bst.newValueRange(lo, hi, bounds)
+		if err != nil {
+			return
+		}
+
+		r.walk(bst.root, yield)
+	}
+}
+
+// CountRange returns the number of values of the binary search tree that lie between lo and hi
+// It uses the subtree sizes, so it runs in O(h) no matter how many values are in the range
+func (bst *BinarySearchTree[T]) CountRange(lo, hi T, bounds Bounds) (int, error) {
+	if bst.IsNil() {
+		return invalidCount, treeNilError
+	}
+
+	upTo, err := bst.countBelow(hi, bounds.includesHigh())
+	if err != nil {
+		return invalidCount, err
+	}
+
+	below, err := bst.countBelow(lo, !bounds.includesLow())
+	if err != nil {
+		return invalidCount, err
+	}
+
+	// inverted bounds make an empty range
+	return max(upTo-below, 0), nil
+}
+
+// DeleteRange removes all the values of the binary search tree that lie between lo and hi,
+// and returns the number of values removed
+func (bst *BinarySearchTree[T]) DeleteRange(lo, hi T, bounds Bounds) (int, error) {
+	if bst.IsNil() {
+		return 0, treeNilError
+	}
+
+	if bst.IsEmpty() {
+		return 0, nil
+	}
+
+	r, err := bst.newValueRange(lo, hi, bounds)
+	if err != nil {
+		return 0, err
+	}
+
+	// collect first, since deleting while walking would change the tree under the walk
+	var doomed []T
+	r.walkNodes(bst.root, func(node *Node[T]) {
+		doomed = append(doomed, node.data)
+	})
+
+	removed := 0
+	for _, val := range doomed {
+		// look the node up again, since removing other nodes may have moved values around
+		node, err2 := bst.findNode(val)
+		if err2 != nil {
+			return removed, err2
+		}
+		removed += node.occurrences
+		bst.removeNode(node)
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,172 @@
+package bstreelib
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestRange(t *testing.T) {
+	var bst *BinarySearchTree[prInt]
+	if got := slices.Collect(bst.Range(0, 10, Closed)); len(got) != 0 {
+		t.Errorf("Range() on a nil tree should not yield any values, got: %v", got)
+	}
+
+	bst, err := ConstructBalancedTree[prInt](10, 20, 30, 40, 50, 60, 70)
+	if err != nil {
+		t.Fatalf("ConstructBalancedTree() failed with error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		lo, hi prInt
+		bounds Bounds
+		want   []prInt
+	}{
+		{"half open", 20, 50, HalfOpen, []prInt{20, 30, 40}},
+		{"closed", 20, 50, Closed, []prInt{20, 30, 40, 50}},
+		{"open", 20, 50, Open, []prInt{30, 40}},
+		{"left open", 20, 50, LeftOpen, []prInt{30, 40, 50}},
+		{"bounds not in the tree", 15, 45, HalfOpen, []prInt{20, 30, 40}},
+		{"whole tree", 0, 100, HalfOpen, []prInt{10, 20, 30, 40, 50, 60, 70}},
+		{"single value", 40, 40, Closed, []prInt{40}},
+		{"empty half open range", 40, 40, HalfOpen, nil},
+		{"below the minimum", 0, 10, HalfOpen, nil},
+		{"above the maximum", 71, 100, Closed, nil},
+		{"inverted bounds", 50, 20, Closed, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := slices.Collect(bst.Range(test.lo, test.hi, test.bounds))
+			if !slices.Equal(got, test.want) {
+				t.Errorf("Range(%v, %v) with bounds %v returned incorrect results, want: %v, got: %v", test.lo, test.hi, test.bounds, test.want, got)
+			}
+
+			gotCnt, err2 := bst.CountRange(test.lo, test.hi, test.bounds)
+			if err2 != nil {
+				t.Fatalf("CountRange() failed with error: %v", err2)
+			} else if gotCnt != len(test.want) {
+				t.Errorf("CountRange(%v, %v) with bounds %v returned incorrect results, want: %v, got: %v", test.lo, test.hi, test.bounds, len(test.want), gotCnt)
+			}
+		})
+	}
+
+	t.Run("break out early", func(t *testing.T) {
+		var got []prInt
+		for val := range bst.Range(0, 100, Closed) {
+			got = append(got, val)
+			if len(got) == 2 {
+				break
+			}
+		}
+
+		want := []prInt{10, 20}
+		if !slices.Equal(got, want) {
+			t.Errorf("Range() returned incorrect results after breaking early, want: %v, got: %v", want, got)
+		}
+	})
+
+	t.Run("subtrees outside the range are skipped", func(t *testing.T) {
+		comparisons := 0
+		counting := NewWithComparator(func(a, b int) int {
+			comparisons += 1
+			return cmp.Compare(a, b)
+		})
+
+		for _, val := range []int{8, 4, 12, 2, 6, 10, 14, 1, 3, 5, 7, 9, 11, 13, 15} {
+			err2 := counting.Insert(val)
+			if err2 != nil {
+				t.Fatalf("Insert() failed with error: %v", err2)
+			}
+		}
+
+		comparisons = 0
+		got := slices.Collect(counting.Range(1, 2, Closed))
+		if !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("Range() returned incorrect results, want: %v, got: %v", []int{1, 2}, got)
+		}
+
+		// visiting every node would need 2 comparisons for each of the 15 nodes
+		if comparisons >= 2*8 {
+			t.Errorf("Range() visited too many nodes, made %v comparisons", comparisons)
+		}
+	})
+}
+
+func TestCountRange(t *testing.T) {
+	var bst *BinarySearchTree[prInt]
+	_, err := bst.CountRange(0, 1, HalfOpen)
+	if !errors.Is(err, treeNilError) {
+		t.Fatalf("CountRange() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	bst = &BinarySearchTree[prInt]{}
+	got, err := bst.CountRange(0, 1, HalfOpen)
+	if err != nil {
+		t.Fatalf("CountRange() failed with error: %v", err)
+	} else if got != 0 {
+		t.Errorf("CountRange() on an empty tree returned incorrect results, want: %v, got: %v", 0, got)
+	}
+}
+
+func TestDeleteRange(t *testing.T) {
+	var bst *BinarySearchTree[prInt]
+	_, err := bst.DeleteRange(0, 1, HalfOpen)
+	if !errors.Is(err, treeNilError) {
+		t.Fatalf("DeleteRange() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	tests := []struct {
+		name        string
+		lo, hi      prInt
+		bounds      Bounds
+		wantDeleted int
+		wantLeft    []prInt
+	}{
+		{"half open", 20, 50, HalfOpen, 3, []prInt{10, 50, 60, 70}},
+		{"closed", 20, 50, Closed, 4, []prInt{10, 60, 70}},
+		{"open", 20, 50, Open, 2, []prInt{10, 20, 50, 60, 70}},
+		{"left open", 20, 50, LeftOpen, 3, []prInt{10, 20, 60, 70}},
+		{"whole tree", 10, 70, Closed, 7, []prInt{}},
+		{"nothing in range", 41, 49, Closed, 0, []prInt{10, 20, 30, 40, 50, 60, 70}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bst, err2 := ConstructBalancedTree[prInt](10, 20, 30, 40, 50, 60, 70)
+			if err2 != nil {
+				t.Fatalf("ConstructBalancedTree() failed with error: %v", err2)
+			}
+
+			deleted, err2 := bst.DeleteRange(test.lo, test.hi, test.bounds)
+			if err2 != nil {
+				t.Fatalf("DeleteRange() failed with error: %v", err2)
+			} else if deleted != test.wantDeleted {
+				t.Errorf("DeleteRange() removed an incorrect number of values, want: %v, got: %v", test.wantDeleted, deleted)
+			}
+
+			left, err2 := bst.ConstructOrderedSlice()
+			if err2 != nil {
+				t.Fatalf("ConstructOrderedSlice() failed with error: %v", err2)
+			} else if !slices.Equal(left, test.wantLeft) {
+				t.Errorf("DeleteRange() gave incorrect results, want: %v, got: %v", test.wantLeft, left)
+			}
+
+			gotCnt, err2 := bst.Count()
+			if err2 != nil {
+				t.Fatalf("Count() failed with error: %v", err2)
+			} else if gotCnt != len(test.wantLeft) {
+				t.Errorf("DeleteRange() left an incorrect count, want: %v, got: %v", len(test.wantLeft), gotCnt)
+			}
+
+			checkParentPointers(t, bst)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package bstreelib
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		policy DuplicatePolicy
+		opts   RenderOptions
+		want   []string
+	}{
+		{"single node", []int{1}, RejectDuplicates, RenderOptions{}, []string{"1"}},
+		{"top down", []int{4, 2, 6, 1, 3, 5, 7}, RejectDuplicates, RenderOptions{}, []string{
+			"   4",
+			" ┌─┴─┐",
+			" 2   6",
+			"┌┴┐ ┌┴┐",
+			"1 3 5 7",
+		}},
+		{"top down ascii", []int{2, 1, 3}, RejectDuplicates, RenderOptions{ASCII: true}, []string{
+			" 2",
+			"+++",
+			"1 3",
+		}},
+		{"sideways", []int{2, 1, 4, 3}, RejectDuplicates, RenderOptions{Layout: Sideways}, []string{
+			"2",
+			"├── 1",
+			"└── 4",
+			"    ├── 3",
+			"    └── nil",
+		}},
+		{"sideways ascii", []int{1, 2, 3}, RejectDuplicates, RenderOptions{Layout: Sideways, ASCII: true}, []string{
+			"1",
+			"|-- nil",
+			"`-- 2",
+			"    |-- nil",
+			"    `-- 3",
+		}},
+		{"duplicate counts", []int{2, 1, 2, 2}, CountDuplicates, RenderOptions{}, []string{
+			" 2 (x3)",
+			"┌───┘",
+			"1",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bst, err := ConstructFromValuesWithPolicy(test.policy, test.values...)
+			if err != nil {
+				t.Fatalf("ConstructFromValuesWithPolicy() failed with error: %v", err)
+			}
+
+			got, err := bst.Render(test.opts)
+			if err != nil {
+				t.Fatalf("Render() failed with error: %v", err)
+			}
+
+			want := strings.Join(test.want, "\n") + "\n"
+			if got != want {
+				t.Errorf("Render() returned incorrect results, want:\n%v\ngot:\n%v", want, got)
+			}
+		})
+	}
+}
+
+func TestRenderBalancedTree(t *testing.T) {
+	bst, err := ConstructFromValues(1, 2, 3, 4, 5, 6, 7)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	err = bst.BalanceTree()
+	if err != nil {
+		t.Fatalf("BalanceTree() failed with error: %v", err)
+	}
+
+	got, err := bst.Render(RenderOptions{Layout: Sideways})
+	if err != nil {
+		t.Fatalf("Render() failed with error: %v", err)
+	}
+
+	want := "4\n├── 2\n│   ├── 1\n│   └── 3\n└── 6\n    ├── 5\n    └── 7\n"
+	if got != want {
+		t.Errorf("Render() returned incorrect results, want:\n%v\ngot:\n%v", want, got)
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	var nilTree *BinarySearchTree[int]
+
+	_, err := nilTree.Render(RenderOptions{})
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("Render() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	_, err = New[int]().Render(RenderOptions{})
+	if !errors.Is(err, treeEmptyError) {
+		t.Errorf("Render() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	bst, err := ConstructFromValues(4, 2, 6, 1, 3, 5, 7)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	_, err = bst.Render(RenderOptions{MaxWidth: 5})
+	if err == nil {
+		t.Errorf("Render() of a tree wider than the maximum width should have failed")
+	} else {
+		fmt.Println(err)
+	}
+
+	got, err := bst.Render(RenderOptions{Layout: Sideways, MaxWidth: 5})
+	if err != nil {
+		t.Fatalf("Render() failed with error: %v", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(got, "\n"), "\n") {
+		if len([]rune(line)) > 5 {
+			t.Errorf("Render() returned a line wider than the maximum width: %q", line)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package bstreelib
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Clone returns a deep copy of the binary search tree, which shares no nodes with the original,
+// along with its comparator, balancing mode and duplicate policy
+func (bst *BinarySearchTree[T]) Clone() (*BinarySearchTree[T], error) {
+	if bst.IsNil() {
+		return nil, treeNilError
+	}
+
+	clone := *bst
+	clone.root = recurseMapNode(bst.root, nil, func(val T) T { return val })
+	return &clone, nil
+}
+
+// MapTree builds a binary search tree with the same shape as the given one, holding the results of f on its values
+// f has to keep the values in strictly increasing order, otherwise the new tree would not be a valid binary search tree
+// The new tree keeps the balancing mode and duplicate policy of the original
+func MapTree[T any, U cmp.Ordered](bst *BinarySearchTree[T], f func(T) U) (*BinarySearchTree[U], error) {
+	return MapTreeWithComparator(bst, f, cmp.Compare[U])
+}
+
+// MapTreeWithComparator is MapTree for value types without a natural ordering, which the given comparator orders instead
+func MapTreeWithComparator[T, U any](bst *BinarySearchTree[T], f func(T) U, compare func(a, b U) int) (*BinarySearchTree[U], error) {
+	if bst.IsNil() {
+		return nil, treeNilError
+	}
+
+	if compare == nil {
+		return nil, noComparatorError
+	}
+
+	mapped := &BinarySearchTree[U]{
+		count:         bst.count,
+		compare:       compare,
+		selfBalancing: bst.selfBalancing,
+		duplicates:    bst.duplicates,
+	}
+	mapped.root = recurseMapNode(bst.root, nil, f)
+
+	if mapped.root == nil {
+		return mapped, nil
+	}
+
+	// the shape is copied as it is, so the mapped values have to come out strictly increasing in order
+	prev := leftmost(mapped.root)
+	for node, _ := prev.Next(); node != nil; node, _ = node.Next() {
+		if compare(prev.data, node.data) >= 0 {
+			return nil, fmt.Errorf("the mapping does not keep the order of the values, %v is followed by %v", prev, node)
+		}
+		prev = node
+	}
+
+	return mapped, nil
+}
+
+// recurseMapNode copies the subtree rooted at the node, applying f to every value, and keeps all the bookkeeping fields
+func recurseMapNode[T, U any](node *Node[T], parent *Node[U], f func(T) U) *Node[U] {
+	if node == nil {
+		return nil
+	}
+
+	copied := &Node[U]{
+		data:        f(node.data),
+		parent:      parent,
+		size:        node.size,
+		height:      node.height,
+		occurrences: node.occurrences,
+	}
+	copied.left = recurseMapNode(node.left, copied, f)
+	copied.right = recurseMapNode(node.right, copied, f)
+	return copied
+}
@@ -0,0 +1,170 @@
+package bstreelib
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	var nilTree *BinarySearchTree[int]
+	_, err := nilTree.Clone()
+	if !errors.Is(err, treeNilError) {
+		t.Errorf("Clone() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	original, err := ConstructAVLFromValues(5, 3, 8, 1, 4, 7, 9)
+	if err != nil {
+		t.Fatalf("ConstructAVLFromValues() failed with error: %v", err)
+	}
+
+	clone, err := original.Clone()
+	if err != nil {
+		t.Fatalf("Clone() failed with error: %v", err)
+	}
+	checkParentPointers(t, clone)
+	checkSubtreeSizes(t, clone)
+
+	if !clone.IsSelfBalancing() {
+		t.Errorf("Clone() should keep the balancing mode of the original")
+	}
+
+	// changes to either tree should not show up in the other
+	for _, val := range []int{10, 11, 12, 13} {
+		err = clone.Insert(val)
+		if err != nil {
+			t.Fatalf("Insert() failed with error: %v", err)
+		}
+	}
+	checkAVLInvariants(t, clone)
+
+	err = original.Delete(5)
+	if err != nil {
+		t.Fatalf("Delete() failed with error: %v", err)
+	}
+
+	want := []int{1, 3, 4, 7, 8, 9}
+	if got := slices.Collect(original.All()); !slices.Equal(got, want) {
+		t.Errorf("the original tree changed with its clone, want: %v, got: %v", want, got)
+	}
+
+	want = []int{1, 3, 4, 5, 7, 8, 9, 10, 11, 12, 13}
+	if got := slices.Collect(clone.All()); !slices.Equal(got, want) {
+		t.Errorf("the clone changed with the original tree, want: %v, got: %v", want, got)
+	}
+
+	// the duplicate policy and the occurrences of each value come along too
+	multiset, err := ConstructFromValuesWithPolicy(CountDuplicates, 2, 1, 2, 3, 2)
+	if err != nil {
+		t.Fatalf("ConstructFromValuesWithPolicy() failed with error: %v", err)
+	}
+
+	clone, err = multiset.Clone()
+	if err != nil {
+		t.Fatalf("Clone() failed with error: %v", err)
+	}
+
+	err = clone.Insert(1)
+	if err != nil {
+		t.Fatalf("Insert() failed with error: %v", err)
+	}
+
+	want = []int{1, 1, 2, 2, 2, 3}
+	if got := slices.Collect(clone.All()); !slices.Equal(got, want) {
+		t.Errorf("Clone() gave incorrect results, want: %v, got: %v", want, got)
+	}
+
+	cnt, err := multiset.Occurrences(1)
+	if err != nil {
+		t.Fatalf("Occurrences() failed with error: %v", err)
+	} else if cnt != 1 {
+		t.Errorf("the original tree changed with its clone, want %v occurrences, got: %v", 1, cnt)
+	}
+}
+
+func TestMapTree(t *testing.T) {
+	bst, err := ConstructFromValues(50, 30, 70, 20, 40, 60, 80)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	doubled, err := MapTree(bst, func(val int) int { return val * 2 })
+	if err != nil {
+		t.Fatalf("MapTree() failed with error: %v", err)
+	}
+	checkParentPointers(t, doubled)
+	checkSubtreeSizes(t, doubled)
+
+	gotBFS, err := doubled.TraverseBFS()
+	if err != nil {
+		t.Fatalf("TraverseBFS() failed with error: %v", err)
+	} else if want := "-(100)--(60)--(140)--(40)--(80)--(120)--(160)-"; gotBFS != want {
+		t.Errorf("MapTree() gave incorrect results, want: %v, got: %v", want, gotBFS)
+	}
+
+	// the mapped tree can hold a different type, and stays searchable
+	labels, err := MapTree(bst, func(val int) string { return fmt.Sprintf("item-%03d", val) })
+	if err != nil {
+		t.Fatalf("MapTree() failed with error: %v", err)
+	}
+
+	found, err := labels.Search("item-060")
+	if err != nil {
+		t.Fatalf("Search() failed with error: %v", err)
+	} else if !found {
+		t.Errorf("Search() on the mapped tree could not find a mapped value")
+	}
+
+	type version struct{ major, minor int }
+	versions, err := MapTreeWithComparator(bst, func(val int) version { return version{val / 10, val % 10} },
+		func(a, b version) int { return cmp.Or(cmp.Compare(a.major, b.major), cmp.Compare(a.minor, b.minor)) })
+	if err != nil {
+		t.Fatalf("MapTreeWithComparator() failed with error: %v", err)
+	}
+
+	err = versions.Insert(version{5, 5})
+	if err != nil {
+		t.Fatalf("Insert() failed with error: %v", err)
+	}
+
+	cnt, err := versions.Count()
+	if err != nil {
+		t.Fatalf("Count() failed with error: %v", err)
+	} else if cnt != 8 {
+		t.Errorf("Count() returned incorrect results, want: %v, got: %v", 8, cnt)
+	}
+
+	tests := []struct {
+		name string
+		f    func(val int) int
+	}{
+		{"reversed order", func(val int) int { return -val }},
+		{"collapsed values", func(val int) int { return val / 100 }},
+		{"one value out of place", func(val int) int {
+			if val == 40 {
+				return 55
+			}
+			return val
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err2 := MapTree(bst, test.f)
+			if err2 == nil {
+				t.Errorf("MapTree() should have failed for a mapping that breaks the order")
+			} else {
+				fmt.Println(err2)
+			}
+		})
+	}
+
+	_, err = MapTreeWithComparator[int, int](bst, func(val int) int { return val }, nil)
+	if !errors.Is(err, noComparatorError) {
+		t.Errorf("MapTreeWithComparator() without a comparator should have failed with error: %v, got: %v", noComparatorError, err)
+	}
+}
@@ -0,0 +1,380 @@
+// Package binfmt: the compact binary encoding of trees shared by the tree packages
+//
+// The layout of an encoded tree is:
+//
+//	magic     4 bytes, "GTRE"
+//	version   1 byte, currently 1
+//	kind      1 byte, which tree type wrote the data
+//	flags     1 byte, bit 0 set when every node carries a count (for multisets)
+//	nodes     uvarint, the number of nodes
+//	shape     the shape bitmap: 2 bits per node in pre-order (has a left child, has a right child),
+//	          packed 64 bits to a word, with every word written as a uvarint
+//	values    for every node in pre-order, its encoded value as a uvarint length followed by that many bytes,
+//	          and then its count as a uvarint, if the flags say so
+//	checksum  4 bytes, the little endian CRC-32 (IEEE) of everything before it
+package binfmt
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"reflect"
+)
+
+const magic = "GTRE"
+
+// Version is the version of the format that Encode writes, and the only one Decode reads
+const Version byte = 1
+
+const countsFlag byte = 1
+
+// the smallest possible encoding: the header with no nodes, and the checksum
+const minLength = len(magic) + 3 + 1 + crc32.Size
+
+// Kind records which tree type wrote the data, so that one type can't read the data of another by mistake
+type Kind byte
+
+const (
+	BinaryTree       Kind = 1
+	BinarySearchTree Kind = 2
+)
+
+// Kind's implementation of the fmt.Stringer interface
+func (kind Kind) String() string {
+	switch kind {
+	case BinaryTree:
+		return "binary tree"
+	case BinarySearchTree:
+		return "binary search tree"
+	}
+	return fmt.Sprintf("unknown kind (%d)", byte(kind))
+}
+
+// Codec turns single values into bytes and back
+// DecodeValue gets exactly the bytes that EncodeValue returned for the value, and should not hold on to them
+type Codec[T any] interface {
+	EncodeValue(value T) ([]byte, error)
+	DecodeValue(data []byte) (T, error)
+}
+
+// Node is a decoded node, with the number of times its value occurs (1 unless the data carries counts)
+type Node[T any] struct {
+	Value       T
+	Count       int
+	Left, Right *Node[T]
+}
+
+// Encode writes the tree rooted at the node in the binary format, using the codec for the values
+// count, when not nil, gives the number of times the value of each node occurs, and gets written along with it
+func Encode[N comparable, T any](kind Kind, root N, children func(N) (N, N), value func(N) T, count func(N) int, codec Codec[T]) ([]byte, error) {
+	var none N
+
+	// list the nodes in pre-order, setting their two bits in the shape bitmap along the way
+	nodes := []N{}
+	words := []uint64{}
+	var recurse func(node N)
+	recurse = func(node N) {
+		index := len(nodes)
+		nodes = append(nodes, node)
+		if index%32 == 0 {
+			words = append(words, 0)
+		}
+
+		left, right := children(node)
+		if left != none {
+			words[index/32] |= 1 << (2 * (index % 32))
+		}
+		if right != none {
+			words[index/32] |= 1 << (2*(index%32) + 1)
+		}
+
+		if left != none {
+			recurse(left)
+		}
+		if right != none {
+			recurse(right)
+		}
+	}
+	if root != none {
+		recurse(root)
+	}
+
+	flags := byte(0)
+	if count != nil {
+		flags |= countsFlag
+	}
+
+	data := append([]byte(magic), Version, byte(kind), flags)
+	data = binary.AppendUvarint(data, uint64(len(nodes)))
+	for _, word := range words {
+		data = binary.AppendUvarint(data, word)
+	}
+
+	for _, node := range nodes {
+		encoded, err := codec.EncodeValue(value(node))
+		if err != nil {
+			return nil, fmt.Errorf("encoding the value %v failed with error: %v", value(node), err)
+		}
+		data = binary.AppendUvarint(data, uint64(len(encoded)))
+		data = append(data, encoded...)
+
+		if count != nil {
+			data = binary.AppendUvarint(data, uint64(count(node)))
+		}
+	}
+
+	return binary.LittleEndian.AppendUint32(data, crc32.ChecksumIEEE(data)), nil
+}
+
+// reader walks through the encoded data, keeping the first error it runs into
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) uvarint(what string) uint64 {
+	if r.err != nil {
+		return 0
+	}
+
+	val, size := binary.Uvarint(r.data)
+	if size <= 0 {
+		r.err = fmt.Errorf("the data ends in the middle of the %v", what)
+		return 0
+	}
+	r.data = r.data[size:]
+	return val
+}
+
+func (r *reader) bytes(length uint64, what string) []byte {
+	if r.err != nil {
+		return nil
+	}
+
+	if length > uint64(len(r.data)) {
+		r.err = fmt.Errorf("the data ends in the middle of the %v", what)
+		return nil
+	}
+	chunk := r.data[:length]
+	r.data = r.data[length:]
+	return chunk
+}
+
+// Decode reads a tree written by Encode with the same kind, using the codec for the values
+// It checks the header and the checksum first, and returns nil for an empty tree
+func Decode[T any](kind Kind, data []byte, codec Codec[T]) (*Node[T], error) {
+	if len(data) < minLength {
+		return nil, fmt.Errorf("the data is too short to hold a tree, it has %v bytes", len(data))
+	}
+
+	if !bytes.HasPrefix(data, []byte(magic)) {
+		return nil, fmt.Errorf("the data does not start with the magic bytes %q", magic)
+	}
+
+	if version := data[len(magic)]; version != Version {
+		return nil, fmt.Errorf("the data has version %v of the format, only version %v can be read", version, Version)
+	}
+
+	if dataKind := Kind(data[len(magic)+1]); dataKind != kind {
+		return nil, fmt.Errorf("the data holds a %v, not a %v", dataKind, kind)
+	}
+
+	body, checksum := data[:len(data)-crc32.Size], binary.LittleEndian.Uint32(data[len(data)-crc32.Size:])
+	if crc32.ChecksumIEEE(body) != checksum {
+		return nil, fmt.Errorf("the checksum does not match the data, it is corrupted")
+	}
+
+	flags := data[len(magic)+2]
+	if flags&^countsFlag != 0 {
+		return nil, fmt.Errorf("the data has unknown flags set: %08b", flags)
+	}
+
+	r := &reader{data: body[len(magic)+3:]}
+	count := r.uvarint("node count")
+
+	// every node takes at least a byte for the length of its value, so a larger count can only come from bad data
+	if r.err == nil && count > uint64(len(r.data)) {
+		return nil, fmt.Errorf("the data claims %v nodes, but only has %v bytes left", count, len(r.data))
+	}
+
+	words := make([]uint64, (count+31)/32)
+	for i := range words {
+		words[i] = r.uvarint("shape bitmap")
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if count == 0 {
+		if len(r.data) > 0 {
+			return nil, fmt.Errorf("the data has %v bytes left over after the tree", len(r.data))
+		}
+		return nil, nil
+	}
+
+	// build the nodes in pre-order, following the shape bitmap
+	next := uint64(0)
+	var recurse func() (*Node[T], error)
+	recurse = func() (*Node[T], error) {
+		if next >= count {
+			return nil, fmt.Errorf("the shape bitmap describes more than the %v nodes in the data", count)
+		}
+		index := next
+		next += 1
+
+		encoded := r.bytes(r.uvarint("value length"), "value")
+		node := &Node[T]{Count: 1}
+		if flags&countsFlag != 0 {
+			occurrences := r.uvarint("count")
+			if r.err == nil && (occurrences == 0 || occurrences > math.MaxInt32) {
+				return nil, fmt.Errorf("node %v has an invalid count: %v", index, occurrences)
+			}
+			node.Count = int(occurrences)
+		}
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		val, err := codec.DecodeValue(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decoding the value of node %v failed with error: %v", index, err)
+		}
+		node.Value = val
+
+		bits := words[index/32] >> (2 * (index % 32))
+		if bits&1 != 0 {
+			node.Left, err = recurse()
+			if err != nil {
+				return nil, err
+			}
+		}
+		if bits&2 != 0 {
+			node.Right, err = recurse()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return node, nil
+	}
+
+	root, err := recurse()
+	if err != nil {
+		return nil, err
+	}
+
+	if next != count {
+		return nil, fmt.Errorf("the shape bitmap describes %v nodes, but the data has %v", next, count)
+	}
+
+	if len(r.data) > 0 {
+		return nil, fmt.Errorf("the data has %v bytes left over after the tree", len(r.data))
+	}
+	return root, nil
+}
+
+// defaultCodec encodes the values of the basic kinds (booleans, integers, floats and strings) directly,
+// types that implement encoding.BinaryMarshaler (with a pointer that implements encoding.BinaryUnmarshaler) with those methods,
+// and everything else as JSON
+type defaultCodec[T any] struct {
+	binary bool // the type has its own binary methods, worked out once when the codec is made
+}
+
+// DefaultCodec returns the codec used when the caller does not pick one
+func DefaultCodec[T any]() Codec[T] {
+	return defaultCodec[T]{binary: binaryMethods[T]()}
+}
+
+// binaryMethods tells you if the type encodes itself with the encoding.BinaryMarshaler and encoding.BinaryUnmarshaler interfaces
+func binaryMethods[T any]() bool {
+	typ := reflect.TypeFor[T]()
+	return typ.Implements(reflect.TypeFor[encoding.BinaryMarshaler]()) &&
+		reflect.PointerTo(typ).Implements(reflect.TypeFor[encoding.BinaryUnmarshaler]())
+}
+
+func (codec defaultCodec[T]) EncodeValue(value T) ([]byte, error) {
+	if codec.binary {
+		return any(value).(encoding.BinaryMarshaler).MarshalBinary()
+	}
+
+	rv := reflect.ValueOf(&value).Elem()
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(nil, rv.Int()), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.AppendUvarint(nil, rv.Uint()), nil
+
+	case reflect.Float32:
+		return binary.LittleEndian.AppendUint32(nil, math.Float32bits(float32(rv.Float()))), nil
+
+	case reflect.Float64:
+		return binary.LittleEndian.AppendUint64(nil, math.Float64bits(rv.Float())), nil
+
+	case reflect.String:
+		return []byte(rv.String()), nil
+	}
+
+	return json.Marshal(value)
+}
+
+func (codec defaultCodec[T]) DecodeValue(data []byte) (T, error) {
+	var value T
+	if codec.binary {
+		err := any(&value).(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+		return value, err
+	}
+
+	rv := reflect.ValueOf(&value).Elem()
+	switch rv.Kind() {
+	case reflect.Bool:
+		if len(data) != 1 || data[0] > 1 {
+			return value, fmt.Errorf("invalid boolean: %v", data)
+		}
+		rv.SetBool(data[0] == 1)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		val, size := binary.Varint(data)
+		if size != len(data) || rv.OverflowInt(val) {
+			return value, fmt.Errorf("invalid %v: %v", rv.Type(), data)
+		}
+		rv.SetInt(val)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		val, size := binary.Uvarint(data)
+		if size != len(data) || rv.OverflowUint(val) {
+			return value, fmt.Errorf("invalid %v: %v", rv.Type(), data)
+		}
+		rv.SetUint(val)
+
+	case reflect.Float32:
+		if len(data) != 4 {
+			return value, fmt.Errorf("invalid %v: %v", rv.Type(), data)
+		}
+		rv.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(data))))
+
+	case reflect.Float64:
+		if len(data) != 8 {
+			return value, fmt.Errorf("invalid %v: %v", rv.Type(), data)
+		}
+		rv.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+
+	case reflect.String:
+		rv.SetString(string(data))
+
+	default:
+		err := json.Unmarshal(data, &value)
+		return value, err
+	}
+
+	return value, nil
+}
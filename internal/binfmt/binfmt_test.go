@@ -0,0 +1,261 @@
+package binfmt
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+type node struct {
+	value       string
+	count       int
+	left, right *node
+}
+
+func children(n *node) (*node, *node) {
+	return n.left, n.right
+}
+
+func value(n *node) string {
+	return n.value
+}
+
+func count(n *node) int {
+	return n.count
+}
+
+func leaf(value string) *node {
+	return &node{value: value, count: 1}
+}
+
+// equalTrees tells you if the decoded tree has the same shape, values and counts as the original
+func equalTrees(original *node, decoded *Node[string]) bool {
+	if original == nil || decoded == nil {
+		return original == nil && decoded == nil
+	}
+	return original.value == decoded.Value && original.count == decoded.Count &&
+		equalTrees(original.left, decoded.Left) && equalTrees(original.right, decoded.Right)
+}
+
+// resign replaces the checksum at the end of the data, so tests can hand Decode data that is bad in other ways
+func resign(data []byte) []byte {
+	body := slices.Clone(data[:len(data)-crc32.Size])
+	return binary.LittleEndian.AppendUint32(body, crc32.ChecksumIEEE(body))
+}
+
+func TestEncodeDecode(t *testing.T) {
+	// more than 32 nodes, so the shape bitmap takes more than one word
+	chain := leaf("end")
+	for i := range 40 {
+		chain = &node{value: fmt.Sprint(i), count: 1, left: chain}
+	}
+
+	tests := []struct {
+		name   string
+		root   *node
+		counts bool
+	}{
+		{"empty tree", nil, false},
+		{"single node", leaf("a"), false},
+		{"full tree", &node{"b", 1, leaf("a"), leaf("c")}, false},
+		{"missing children", &node{"a", 1, nil, &node{"c", 1, leaf("b"), nil}}, false},
+		{"long chain", chain, false},
+		{"empty values", &node{"", 1, leaf(""), nil}, false},
+		{"counts", &node{"b", 3, leaf("a"), &node{"c", 200, nil, nil}}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var counts func(*node) int
+			if test.counts {
+				counts = count
+			}
+
+			data, err := Encode(BinarySearchTree, test.root, children, value, counts, DefaultCodec[string]())
+			if err != nil {
+				t.Fatalf("Encode() failed with error: %v", err)
+			}
+
+			decoded, err := Decode(BinarySearchTree, data, DefaultCodec[string]())
+			if err != nil {
+				t.Fatalf("Decode() failed with error: %v", err)
+			}
+
+			if !equalTrees(test.root, decoded) {
+				t.Errorf("Decode() did not give back the encoded tree")
+			}
+		})
+	}
+}
+
+func TestEncodeLayout(t *testing.T) {
+	data, err := Encode(BinaryTree, &node{"b", 1, leaf("a"), nil}, children, value, nil, DefaultCodec[string]())
+	if err != nil {
+		t.Fatalf("Encode() failed with error: %v", err)
+	}
+
+	want := []byte{
+		'G', 'T', 'R', 'E', // magic
+		Version, byte(BinaryTree), 0, // version, kind, flags
+		2,      // nodes
+		0b01,   // the root only has a left child, the leaf has none
+		1, 'b', // the root's value
+		1, 'a', // the leaf's value
+	}
+	want = binary.LittleEndian.AppendUint32(want, crc32.ChecksumIEEE(want))
+
+	if !slices.Equal(data, want) {
+		t.Errorf("Encode() returned incorrect results, want: %v, got: %v", want, data)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tree := &node{"b", 1, leaf("a"), leaf("c")}
+	valid, err := Encode(BinaryTree, tree, children, value, nil, DefaultCodec[string]())
+	if err != nil {
+		t.Fatalf("Encode() failed with error: %v", err)
+	}
+
+	header := len(magic) + 3
+	edit := func(index int, b byte) []byte {
+		data := slices.Clone(valid)
+		data[index] = b
+		return data
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"no data", nil},
+		{"too short", valid[:minLength-1]},
+		{"wrong magic", resign(edit(0, 'X'))},
+		{"newer version", resign(edit(len(magic), Version+1))},
+		{"wrong kind", resign(edit(len(magic)+1, byte(BinarySearchTree)))},
+		{"corrupted value", edit(len(valid)-crc32.Size-1, 'z')},
+		{"corrupted checksum", edit(len(valid)-1, valid[len(valid)-1]+1)},
+		{"truncated", resign(valid[:len(valid)-crc32.Size-1])},
+		{"unknown flags", resign(edit(len(magic)+2, 0b10))},
+		{"too many nodes claimed", resign(edit(header, 100))},
+		{"shape describes fewer nodes", resign(edit(header+1, 0b0001))},
+		{"shape describes more nodes", resign(edit(header+1, 0b1011))},
+		{"extra bytes", resign(append(valid[:len(valid)-crc32.Size:len(valid)-crc32.Size], 0, 0, 0, 0, 0))},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err2 := Decode(BinaryTree, test.data, DefaultCodec[string]())
+			if err2 == nil {
+				t.Fatalf("Decode() should have failed")
+			}
+			fmt.Println(err2)
+		})
+	}
+
+	// a count of zero can't come from Encode
+	counted, err := Encode(BinarySearchTree, leaf("a"), children, value, func(*node) int { return 0 }, DefaultCodec[string]())
+	if err != nil {
+		t.Fatalf("Encode() failed with error: %v", err)
+	}
+	_, err = Decode(BinarySearchTree, counted, DefaultCodec[string]())
+	if err == nil {
+		t.Errorf("Decode() of a zero count should have failed")
+	} else {
+		fmt.Println(err)
+	}
+}
+
+type myInt int16
+type point struct {
+	X, Y int
+}
+
+// roundTrip runs a value through the default codec
+func roundTrip[T any](t *testing.T, val T) T {
+	t.Helper()
+
+	codec := DefaultCodec[T]()
+	data, err := codec.EncodeValue(val)
+	if err != nil {
+		t.Fatalf("EncodeValue() failed with error: %v", err)
+	}
+
+	got, err := codec.DecodeValue(data)
+	if err != nil {
+		t.Fatalf("DecodeValue() failed with error: %v", err)
+	}
+	return got
+}
+
+func TestDefaultCodec(t *testing.T) {
+	moment := time.Date(2024, 2, 29, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		equal bool
+	}{
+		{"bool", roundTrip(t, true) == true},
+		{"negative int", roundTrip(t, -12345) == -12345},
+		{"named int", roundTrip(t, myInt(math.MinInt16)) == myInt(math.MinInt16)},
+		{"max uint64", roundTrip(t, uint64(math.MaxUint64)) == math.MaxUint64},
+		{"float32", roundTrip(t, float32(0.1)) == float32(0.1)},
+		{"negative zero", math.Signbit(roundTrip(t, math.Copysign(0, -1)))},
+		{"infinity", math.IsInf(roundTrip(t, math.Inf(-1)), -1)},
+		{"string", roundTrip(t, "héllo\x00") == "héllo\x00"},
+		{"binary marshaler", roundTrip(t, moment).Equal(moment)},
+		{"json fallback", roundTrip(t, point{3, -4}) == point{3, -4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !test.equal {
+				t.Errorf("the value did not survive the default codec")
+			}
+		})
+	}
+
+	// values that don't fit their type get rejected
+	_, err := DefaultCodec[int8]().DecodeValue(binary.AppendVarint(nil, 300))
+	if err == nil {
+		t.Errorf("DecodeValue() of an overflowing int8 should have failed")
+	} else {
+		fmt.Println(err)
+	}
+
+	_, err = DefaultCodec[bool]().DecodeValue([]byte{2})
+	if err == nil {
+		t.Errorf("DecodeValue() of an invalid bool should have failed")
+	} else {
+		fmt.Println(err)
+	}
+
+	_, err = DefaultCodec[float64]().DecodeValue([]byte{1, 2})
+	if err == nil {
+		t.Errorf("DecodeValue() of a short float should have failed")
+	} else {
+		fmt.Println(err)
+	}
+
+	// a float32 takes 4 bytes, so the 8 bytes of a float64 don't read back as one
+	data, err := DefaultCodec[float32]().EncodeValue(0.1)
+	if err != nil {
+		t.Fatalf("EncodeValue() failed with error: %v", err)
+	} else if len(data) != 4 {
+		t.Errorf("EncodeValue() of a float32 should take 4 bytes, got: %v", data)
+	}
+
+	data, err = DefaultCodec[float64]().EncodeValue(0.1)
+	if err != nil {
+		t.Fatalf("EncodeValue() failed with error: %v", err)
+	}
+	_, err = DefaultCodec[float32]().DecodeValue(data)
+	if err == nil {
+		t.Errorf("DecodeValue() of a float64 into a float32 should have failed")
+	} else {
+		fmt.Println(err)
+	}
+}
@@ -0,0 +1,59 @@
+// Package ordering: comparator helpers shared by the tree packages
+package ordering
+
+import (
+	"cmp"
+	"reflect"
+)
+
+// Comparator builds a comparator for element types whose underlying type is ordered (integers, floats and strings),
+// so that the zero value of a tree works for those types without an explicit comparator
+// Values of the basic types themselves get compared directly, and only values of named types go through reflection
+// It returns nil for any other element type
+func Comparator[T any]() func(a, b T) int {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int:
+		return underlying[T, int]()
+	case reflect.Int8:
+		return underlying[T, int8]()
+	case reflect.Int16:
+		return underlying[T, int16]()
+	case reflect.Int32:
+		return underlying[T, int32]()
+	case reflect.Int64:
+		return underlying[T, int64]()
+	case reflect.Uint:
+		return underlying[T, uint]()
+	case reflect.Uint8:
+		return underlying[T, uint8]()
+	case reflect.Uint16:
+		return underlying[T, uint16]()
+	case reflect.Uint32:
+		return underlying[T, uint32]()
+	case reflect.Uint64:
+		return underlying[T, uint64]()
+	case reflect.Uintptr:
+		return underlying[T, uintptr]()
+	case reflect.Float32:
+		return underlying[T, float32]()
+	case reflect.Float64:
+		return underlying[T, float64]()
+	case reflect.String:
+		return underlying[T, string]()
+	}
+
+	return nil
+}
+
+// underlying compares values of type T as values of U, which has to be the underlying type of T
+func underlying[T any, U cmp.Ordered]() func(a, b T) int {
+	if compare, ok := any(cmp.Compare[U]).(func(a, b T) int); ok {
+		return compare // T is U itself
+	}
+
+	// T is a named type over U, so convert its values to U first
+	to := reflect.TypeFor[U]()
+	return func(a, b T) int {
+		return cmp.Compare(reflect.ValueOf(a).Convert(to).Interface().(U), reflect.ValueOf(b).Convert(to).Interface().(U))
+	}
+}
@@ -0,0 +1,68 @@
+package ordering
+
+import (
+	"math"
+	"testing"
+)
+
+type myInt int
+type myUint uint8
+type myFloat float64
+type myString string
+type myInt32 int32
+type myUintptr uintptr
+type myFloat32 float32
+
+func TestComparator(t *testing.T) {
+
+	t.Run("ordered kinds", func(t *testing.T) {
+		tests := []struct {
+			name string
+			got  []int
+			want []int
+		}{
+			{"int", compareAll(Comparator[int](), 1, 2), []int{-1, 0, 1}},
+			{"named int", compareAll(Comparator[myInt](), -5, 3), []int{-1, 0, 1}},
+			{"named uint", compareAll(Comparator[myUint](), 0, 255), []int{-1, 0, 1}},
+			{"named float", compareAll(Comparator[myFloat](), -0.5, 0.25), []int{-1, 0, 1}},
+			{"NaN", compareAll(Comparator[float64](), math.NaN(), 0), []int{-1, 0, 1}},
+			{"named string", compareAll(Comparator[myString](), "apple", "banana"), []int{-1, 0, 1}},
+			{"int8 extremes", compareAll(Comparator[int8](), math.MinInt8, math.MaxInt8), []int{-1, 0, 1}},
+			{"named int32", compareAll(Comparator[myInt32](), -1, 0), []int{-1, 0, 1}},
+			{"uint64 extremes", compareAll(Comparator[uint64](), 0, math.MaxUint64), []int{-1, 0, 1}},
+			{"named uintptr", compareAll(Comparator[myUintptr](), 1, 2), []int{-1, 0, 1}},
+			{"named float32", compareAll(Comparator[myFloat32](), -1.5, 1e-3), []int{-1, 0, 1}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				for i := range test.want {
+					if test.got[i] != test.want[i] {
+						t.Errorf("Comparator() gave incorrect results, want: %v, got: %v", test.want, test.got)
+						break
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("unordered kinds", func(t *testing.T) {
+		if Comparator[struct{ x int }]() != nil {
+			t.Errorf("Comparator() for a struct type should be nil")
+		}
+		if Comparator[*int]() != nil {
+			t.Errorf("Comparator() for a pointer type should be nil")
+		}
+		if Comparator[any]() != nil {
+			t.Errorf("Comparator() for an interface type should be nil")
+		}
+	})
+}
+
+// compareAll compares (lo, hi), (lo, lo) and (hi, lo) with the given comparator, and returns the normalized results
+func compareAll[T any](compare func(a, b T) int, lo, hi T) []int {
+	sign := func(x int) int {
+		return min(max(x, -1), 1)
+	}
+	return []int{sign(compare(lo, hi)), sign(compare(lo, lo)), sign(compare(hi, lo))}
+}
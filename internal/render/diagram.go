@@ -0,0 +1,170 @@
+package render
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Attributes are extra settings for a single node of a diagram, keyed by name, like "color" or "label"
+// A "label" attribute replaces the node's value as its text
+type Attributes map[string]string
+
+// Format writes a tree as a diagram, like DOT and Mermaid do
+type Format[N comparable] func(root N, children func(N) (N, N), label func(N) string, attributes func(N) Attributes) string
+
+// diagramNode is a node of the tree along with the id it gets in a diagram
+type diagramNode[N comparable] struct {
+	node N
+	id   string
+}
+
+// walkDiagram hands out ids to the nodes in pre-order (n0 for the root), and calls edge for every parent child pair,
+// left child first, with side "L" or "R"
+// A node with just one child also gets a placeholder for the missing child (with a nil node and an id like nil3),
+// so diagram tools that lay out children in order still place the present child on the correct side
+func walkDiagram[N comparable](root N, children func(N) (N, N), visit func(node diagramNode[N]), edge func(parent, child diagramNode[N], side string)) {
+	var none N
+	next := 0
+	newID := func(prefix string) string {
+		id := fmt.Sprintf("%v%v", prefix, next)
+		next += 1
+		return id
+	}
+
+	var recurse func(current diagramNode[N])
+	recurse = func(current diagramNode[N]) {
+		visit(current)
+
+		left, right := children(current.node)
+		if left == none && right == none {
+			return
+		}
+
+		for _, child := range []struct {
+			node N
+			side string
+		}{{left, "L"}, {right, "R"}} {
+			if child.node == none {
+				edge(current, diagramNode[N]{id: newID("nil")}, child.side)
+				continue
+			}
+
+			childNode := diagramNode[N]{node: child.node, id: newID("n")}
+			edge(current, childNode, child.side)
+			recurse(childNode)
+		}
+	}
+
+	recurse(diagramNode[N]{node: root, id: newID("n")})
+}
+
+// nodeAttributes returns the attributes of the node, with the label first and the rest sorted by name
+// attributes can be nil, in which case the node just gets its label
+func nodeAttributes[N comparable](node N, label func(N) string, attributes func(N) Attributes) (string, [][2]string) {
+	text := label(node)
+	rest := [][2]string{}
+	if attributes == nil {
+		return text, rest
+	}
+
+	extra := attributes(node)
+	for _, name := range slices.Sorted(maps.Keys(extra)) {
+		if name == "label" {
+			text = extra[name]
+			continue
+		}
+		rest = append(rest, [2]string{name, extra[name]})
+	}
+	return text, rest
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// dotQuote turns the text into a quoted DOT string
+func dotQuote(text string) string {
+	return `"` + dotEscaper.Replace(text) + `"`
+}
+
+// DOT returns the tree as a Graphviz digraph, with every edge labelled L or R, and invisible placeholders for missing children
+// The nodes get the given attributes on top of their label, exactly as Graphviz names them (color, shape, style...)
+// An empty tree gives an empty digraph
+func DOT[N comparable](root N, children func(N) (N, N), label func(N) string, attributes func(N) Attributes) string {
+	builder := &strings.Builder{}
+	builder.WriteString("digraph {\n")
+	builder.WriteString("\tgraph [ordering=out];\n")
+
+	var none N
+	if root != none {
+		walkDiagram(root, children,
+			func(node diagramNode[N]) {
+				text, rest := nodeAttributes(node.node, label, attributes)
+				fmt.Fprintf(builder, "\t%v [label=%v", node.id, dotQuote(text))
+				for _, attribute := range rest {
+					fmt.Fprintf(builder, ", %v=%v", dotQuote(attribute[0]), dotQuote(attribute[1]))
+				}
+				builder.WriteString("];\n")
+			},
+			func(parent, child diagramNode[N], side string) {
+				if child.node == none {
+					fmt.Fprintf(builder, "\t%v [style=invis];\n", child.id)
+					fmt.Fprintf(builder, "\t%v -> %v [style=invis];\n", parent.id, child.id)
+					return
+				}
+				fmt.Fprintf(builder, "\t%v -> %v [label=%v];\n", parent.id, child.id, side)
+			})
+	}
+
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+var mermaidEscaper = strings.NewReplacer(`"`, "#quot;", "\n", "<br>")
+
+// Mermaid returns the tree as a Mermaid top-down flowchart (graph TD), with every edge labelled L or R,
+// and hidden placeholders joined by invisible links for missing children
+// Besides the label, the attributes become a style line for the node, so they should be CSS properties (fill, stroke, color...)
+// An empty tree gives a flowchart with no nodes
+func Mermaid[N comparable](root N, children func(N) (N, N), label func(N) string, attributes func(N) Attributes) string {
+	builder := &strings.Builder{}
+	builder.WriteString("graph TD\n")
+
+	var none N
+	if root == none {
+		return builder.String()
+	}
+
+	styles := []string{}
+	placeholders := false
+	walkDiagram(root, children,
+		func(node diagramNode[N]) {
+			text, rest := nodeAttributes(node.node, label, attributes)
+			fmt.Fprintf(builder, "\t%v[\"%v\"]\n", node.id, mermaidEscaper.Replace(text))
+
+			if len(rest) > 0 {
+				properties := []string{}
+				for _, attribute := range rest {
+					properties = append(properties, attribute[0]+":"+attribute[1])
+				}
+				styles = append(styles, fmt.Sprintf("\tstyle %v %v\n", node.id, strings.Join(properties, ",")))
+			}
+		},
+		func(parent, child diagramNode[N], side string) {
+			if child.node == none {
+				placeholders = true
+				fmt.Fprintf(builder, "\t%v[\" \"]:::invisible\n", child.id)
+				fmt.Fprintf(builder, "\t%v ~~~ %v\n", parent.id, child.id)
+				return
+			}
+			fmt.Fprintf(builder, "\t%v -->|%v| %v\n", parent.id, side, child.id)
+		})
+
+	for _, style := range styles {
+		builder.WriteString(style)
+	}
+	if placeholders {
+		builder.WriteString("\tclassDef invisible display:none;\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,127 @@
+package render
+
+import (
+	"testing"
+)
+
+func TestDOT(t *testing.T) {
+	tree := &node{"1", &node{"2", nil, leaf("4")}, leaf("3")}
+	colors := func(n *node) Attributes {
+		if n.label == "4" {
+			return Attributes{"label": `four "4"`, "color": "red", "shape": "box"}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name       string
+		root       *node
+		attributes func(*node) Attributes
+		want       []string
+	}{
+		{"empty tree", nil, nil, []string{
+			"digraph {",
+			"\tgraph [ordering=out];",
+			"}",
+		}},
+		{"single node", leaf("1"), nil, []string{
+			"digraph {",
+			"\tgraph [ordering=out];",
+			"\tn0 [label=\"1\"];",
+			"}",
+		}},
+		{"missing child", tree, nil, []string{
+			"digraph {",
+			"\tgraph [ordering=out];",
+			"\tn0 [label=\"1\"];",
+			"\tn0 -> n1 [label=L];",
+			"\tn1 [label=\"2\"];",
+			"\tnil2 [style=invis];",
+			"\tn1 -> nil2 [style=invis];",
+			"\tn1 -> n3 [label=R];",
+			"\tn3 [label=\"4\"];",
+			"\tn0 -> n4 [label=R];",
+			"\tn4 [label=\"3\"];",
+			"}",
+		}},
+		{"attributes", tree, colors, []string{
+			"digraph {",
+			"\tgraph [ordering=out];",
+			"\tn0 [label=\"1\"];",
+			"\tn0 -> n1 [label=L];",
+			"\tn1 [label=\"2\"];",
+			"\tnil2 [style=invis];",
+			"\tn1 -> nil2 [style=invis];",
+			"\tn1 -> n3 [label=R];",
+			"\tn3 [label=\"four \\\"4\\\"\", \"color\"=\"red\", \"shape\"=\"box\"];",
+			"\tn0 -> n4 [label=R];",
+			"\tn4 [label=\"3\"];",
+			"}",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := DOT(test.root, children, label, test.attributes)
+			want := lines(test.want...)
+			if got != want {
+				t.Errorf("DOT() returned incorrect results, want:\n%v\ngot:\n%v", want, got)
+			}
+		})
+	}
+}
+
+func TestMermaid(t *testing.T) {
+	tree := &node{"1", leaf("2"), &node{"3", leaf(`"5"`), nil}}
+	styles := func(n *node) Attributes {
+		if n.label == "3" {
+			return Attributes{"fill": "#f96", "stroke": "#333"}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name       string
+		root       *node
+		attributes func(*node) Attributes
+		want       []string
+	}{
+		{"empty tree", nil, nil, []string{"graph TD"}},
+		{"single node", leaf("1"), nil, []string{
+			"graph TD",
+			"\tn0[\"1\"]",
+		}},
+		{"full tree", &node{"1", leaf("2"), leaf("3")}, nil, []string{
+			"graph TD",
+			"\tn0[\"1\"]",
+			"\tn0 -->|L| n1",
+			"\tn1[\"2\"]",
+			"\tn0 -->|R| n2",
+			"\tn2[\"3\"]",
+		}},
+		{"missing child with styles", tree, styles, []string{
+			"graph TD",
+			"\tn0[\"1\"]",
+			"\tn0 -->|L| n1",
+			"\tn1[\"2\"]",
+			"\tn0 -->|R| n2",
+			"\tn2[\"3\"]",
+			"\tn2 -->|L| n3",
+			"\tn3[\"#quot;5#quot;\"]",
+			"\tnil4[\" \"]:::invisible",
+			"\tn2 ~~~ nil4",
+			"\tstyle n2 fill:#f96,stroke:#333",
+			"\tclassDef invisible display:none;",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Mermaid(test.root, children, label, test.attributes)
+			want := lines(test.want...)
+			if got != want {
+				t.Errorf("Mermaid() returned incorrect results, want:\n%v\ngot:\n%v", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+// Package render: text drawings of tree structure shared by the tree packages
+// Every function takes the root node, a function giving the two children of a node and a function giving its label,
+// where the zero value of the node type (a nil pointer) stands for a missing node
+package render
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Layout picks how the tree is drawn
+type Layout int
+
+const (
+	TopDown  Layout = iota // the root on the first line, and each level on the lines below it, the zero value
+	Sideways               // one node per line, indented under its parent, with the left child listed first
+)
+
+// Layout's implementation of the fmt.Stringer interface
+func (layout Layout) String() string {
+	switch layout {
+	case TopDown:
+		return "top down"
+	case Sideways:
+		return "sideways"
+	}
+	return fmt.Sprintf("unknown layout (%d)", int(layout))
+}
+
+// Options controls the drawing of a tree
+type Options struct {
+	Layout   Layout
+	ASCII    bool // draw the connectors with plain ASCII characters instead of box drawing ones
+	MaxWidth int  // the most characters a line can take, or 0 for no limit
+}
+
+// charset holds the characters used for the connecting lines
+type charset struct {
+	downRight, downLeft, upRight, upLeft, horizontal, teeUp string // for the top down layout
+	branch, lastBranch, vertical, space                     string // for the sideways layout
+	ellipsis                                                string
+}
+
+var unicodeChars = charset{"┌", "┐", "└", "┘", "─", "┴", "├── ", "└── ", "│   ", "    ", "…"}
+var asciiChars = charset{"+", "+", "+", "+", "-", "+", "|-- ", "`-- ", "|   ", "    ", "..."}
+
+func (opts Options) chars() charset {
+	if opts.ASCII {
+		return asciiChars
+	}
+	return unicodeChars
+}
+
+// Draw renders the tree as text using the given options, or returns an error if the options can't be met
+// An empty tree is drawn as an empty string
+func Draw[N comparable](root N, children func(N) (N, N), label func(N) string, opts Options) (string, error) {
+	if opts.MaxWidth < 0 {
+		return "", fmt.Errorf("the maximum width can't be negative, got: %v", opts.MaxWidth)
+	}
+
+	var none N
+	if root == none {
+		return "", nil
+	}
+
+	switch opts.Layout {
+	case TopDown:
+		return drawTopDown(root, children, label, opts)
+	case Sideways:
+		return drawSideways(root, children, label, opts), nil
+	}
+	return "", fmt.Errorf("invalid layout: %v", opts.Layout)
+}
+
+// block is the drawing of a subtree: lines of equal width, and the column right above which its root sits
+type block struct {
+	lines  []string
+	width  int
+	middle int
+}
+
+func drawTopDown[N comparable](root N, children func(N) (N, N), label func(N) string, opts Options) (string, error) {
+	drawing := recurseBlock(root, children, label, opts.chars())
+
+	if opts.MaxWidth > 0 && drawing.width > opts.MaxWidth {
+		return "", fmt.Errorf("the tree needs %v columns, which is more than the maximum width of %v, the sideways layout fits in less", drawing.width, opts.MaxWidth)
+	}
+
+	for i, line := range drawing.lines {
+		drawing.lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(drawing.lines, "\n") + "\n", nil
+}
+
+// recurseBlock draws the subtree rooted at the node: the label on the first line, the connectors on the second,
+// and the drawings of the two subtrees side by side below them
+func recurseBlock[N comparable](node N, children func(N) (N, N), label func(N) string, chars charset) block {
+	var none N
+	text := label(node)
+	size := utf8.RuneCountInString(text)
+
+	left, right := children(node)
+	if left == none && right == none {
+		return block{lines: []string{text}, width: size, middle: size / 2}
+	}
+
+	var leftBlock, rightBlock block
+	if left != none {
+		leftBlock = recurseBlock(left, children, label, chars)
+	}
+	if right != none {
+		rightBlock = recurseBlock(right, children, label, chars)
+	}
+
+	// work out where the two subtrees go, and where the label sits between them
+	rightOffset, leftRoot, rightRoot, labelStart := 0, -1, -1, 0
+	switch {
+	case left != none && right != none:
+		rightOffset = leftBlock.width + 1
+		leftRoot, rightRoot = leftBlock.middle, rightOffset+rightBlock.middle
+		labelStart = (leftRoot+rightRoot)/2 - size/2
+	case left != none:
+		leftRoot = leftBlock.middle
+		labelStart = leftRoot + 1
+	default:
+		// the label sits left of the right subtree's root, so push the subtree over if it does not
+		rightOffset = max(0, size/2+1-rightBlock.middle)
+		rightRoot = rightOffset + rightBlock.middle
+	}
+
+	// a label wider than the room between the subtrees moves them right, or widens the block
+	shift := max(0, -labelStart)
+	labelStart += shift
+	width := max(labelStart+size, shift+rightOffset+rightBlock.width, shift+leftBlock.width)
+	labelMiddle := labelStart + size/2
+
+	lines := []string{pad(strings.Repeat(" ", labelStart)+text, width)}
+
+	connector := []string{}
+	for col := 0; col < width; col++ {
+		switch {
+		case col == leftRoot+shift && left != none:
+			connector = append(connector, chars.downRight)
+		case col == rightRoot+shift && right != none:
+			connector = append(connector, chars.downLeft)
+		case col == labelMiddle && left != none && right != none:
+			connector = append(connector, chars.teeUp)
+		case col == labelMiddle && left != none:
+			connector = append(connector, chars.upLeft)
+		case col == labelMiddle:
+			connector = append(connector, chars.upRight)
+		case (left != none && col > leftRoot+shift && col < labelMiddle) || (right != none && col > labelMiddle && col < rightRoot+shift):
+			connector = append(connector, chars.horizontal)
+		default:
+			connector = append(connector, " ")
+		}
+	}
+	lines = append(lines, strings.Join(connector, ""))
+
+	for i := 0; i < max(len(leftBlock.lines), len(rightBlock.lines)); i++ {
+		line := strings.Repeat(" ", shift)
+		if i < len(leftBlock.lines) {
+			line += leftBlock.lines[i]
+		} else {
+			line += strings.Repeat(" ", leftBlock.width)
+		}
+
+		if i < len(rightBlock.lines) {
+			line = pad(line, shift+rightOffset) + rightBlock.lines[i]
+		}
+		lines = append(lines, pad(line, width))
+	}
+
+	return block{lines: lines, width: width, middle: labelMiddle}
+}
+
+// pad adds spaces to the end of the line till it is the given number of characters wide
+func pad(line string, width int) string {
+	return line + strings.Repeat(" ", max(0, width-utf8.RuneCountInString(line)))
+}
+
+func drawSideways[N comparable](root N, children func(N) (N, N), label func(N) string, opts Options) string {
+	chars := opts.chars()
+	builder := &strings.Builder{}
+
+	var recurse func(node N, prefix, branch, childPrefix string)
+	recurse = func(node N, prefix, branch, childPrefix string) {
+		var none N
+		if node == none {
+			writeLine(builder, prefix+branch+"nil", opts.MaxWidth, chars)
+			return
+		}
+		writeLine(builder, prefix+branch+label(node), opts.MaxWidth, chars)
+
+		// a single child is still drawn next to a nil, so it is clear which side it hangs on
+		left, right := children(node)
+		if left == none && right == none {
+			return
+		}
+		recurse(left, prefix+childPrefix, chars.branch, chars.vertical)
+		recurse(right, prefix+childPrefix, chars.lastBranch, chars.space)
+	}
+
+	recurse(root, "", "", "")
+	return builder.String()
+}
+
+// writeLine writes the line, cutting it short with an ellipsis if it is wider than the maximum width
+func writeLine(builder *strings.Builder, line string, maxWidth int, chars charset) {
+	if maxWidth > 0 && utf8.RuneCountInString(line) > maxWidth {
+		keep := max(0, maxWidth-utf8.RuneCountInString(chars.ellipsis))
+		line = string([]rune(line)[:keep]) + chars.ellipsis
+		if utf8.RuneCountInString(line) > maxWidth {
+			line = string([]rune(line)[:maxWidth])
+		}
+	}
+	builder.WriteString(line)
+	builder.WriteString("\n")
+}
@@ -0,0 +1,178 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type node struct {
+	label       string
+	left, right *node
+}
+
+func children(n *node) (*node, *node) {
+	return n.left, n.right
+}
+
+func label(n *node) string {
+	return n.label
+}
+
+func leaf(label string) *node {
+	return &node{label: label}
+}
+
+// lines joins the lines of an expected drawing, adding the trailing newline
+func lines(rows ...string) string {
+	return strings.Join(rows, "\n") + "\n"
+}
+
+func TestDraw(t *testing.T) {
+	perfect := &node{"1", &node{"2", leaf("4"), leaf("5")}, &node{"3", leaf("6"), leaf("7")}}
+	leftOnly := &node{"1", leaf("2"), nil}
+	rightOnly := &node{"1", nil, leaf("2")}
+	wideLabels := &node{"100", &node{"2", leaf("4"), leaf("5")}, leaf("3000")}
+	zigZag := &node{"1", nil, &node{"2", &node{"3", nil, leaf("4")}, nil}}
+
+	tests := []struct {
+		name string
+		root *node
+		opts Options
+		want string
+	}{
+		{"empty tree", nil, Options{}, ""},
+		{"single node", leaf("1"), Options{}, lines("1")},
+		{"top down perfect", perfect, Options{}, lines(
+			"   1",
+			" ┌─┴─┐",
+			" 2   3",
+			"┌┴┐ ┌┴┐",
+			"4 5 6 7",
+		)},
+		{"top down ascii", perfect, Options{ASCII: true}, lines(
+			"   1",
+			" +-+-+",
+			" 2   3",
+			"+++ +++",
+			"4 5 6 7",
+		)},
+		{"top down left child only", leftOnly, Options{}, lines(
+			" 1",
+			"┌┘",
+			"2",
+		)},
+		{"top down right child only", rightOnly, Options{}, lines(
+			"1",
+			"└┐",
+			" 2",
+		)},
+		{"top down wide labels", wideLabels, Options{}, lines(
+			"  100",
+			" ┌─┴──┐",
+			" 2  3000",
+			"┌┴┐",
+			"4 5",
+		)},
+		{"top down zig zag", zigZag, Options{}, lines(
+			"1",
+			"└┐",
+			" 2",
+			"┌┘",
+			"3",
+			"└┐",
+			" 4",
+		)},
+		{"top down within max width", perfect, Options{MaxWidth: 7}, lines(
+			"   1",
+			" ┌─┴─┐",
+			" 2   3",
+			"┌┴┐ ┌┴┐",
+			"4 5 6 7",
+		)},
+		{"sideways perfect", perfect, Options{Layout: Sideways}, lines(
+			"1",
+			"├── 2",
+			"│   ├── 4",
+			"│   └── 5",
+			"└── 3",
+			"    ├── 6",
+			"    └── 7",
+		)},
+		{"sideways ascii", perfect, Options{Layout: Sideways, ASCII: true}, lines(
+			"1",
+			"|-- 2",
+			"|   |-- 4",
+			"|   `-- 5",
+			"`-- 3",
+			"    |-- 6",
+			"    `-- 7",
+		)},
+		{"sideways missing children", zigZag, Options{Layout: Sideways}, lines(
+			"1",
+			"├── nil",
+			"└── 2",
+			"    ├── 3",
+			"    │   ├── nil",
+			"    │   └── 4",
+			"    └── nil",
+		)},
+		{"sideways max width", wideLabels, Options{Layout: Sideways, MaxWidth: 7}, lines(
+			"100",
+			"├── 2",
+			"│   ├─…",
+			"│   └─…",
+			"└── 30…",
+		)},
+		{"sideways ascii max width", wideLabels, Options{Layout: Sideways, ASCII: true, MaxWidth: 7}, lines(
+			"100",
+			"|-- 2",
+			"|   ...",
+			"|   ...",
+			"`-- ...",
+		)},
+		{"sideways tiny max width", wideLabels, Options{Layout: Sideways, ASCII: true, MaxWidth: 2}, lines(
+			"..",
+			"..",
+			"..",
+			"..",
+			"..",
+		)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Draw(test.root, children, label, test.opts)
+			if err != nil {
+				t.Fatalf("Draw() failed with error: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("Draw() returned incorrect results, want:\n%v\ngot:\n%v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestDrawErrors(t *testing.T) {
+	perfect := &node{"1", &node{"2", leaf("4"), leaf("5")}, &node{"3", leaf("6"), leaf("7")}}
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"negative max width", Options{MaxWidth: -1}},
+		{"top down too wide", Options{MaxWidth: 6}},
+		{"invalid layout", Options{Layout: Layout(5)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Draw(perfect, children, label, test.opts)
+			if err == nil {
+				t.Fatalf("Draw() should have returned an error")
+			}
+			fmt.Println(err)
+		})
+	}
+}
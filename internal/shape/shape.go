@@ -0,0 +1,190 @@
+// Package shape: structural measurements shared by the tree packages
+// Every function takes the root node and a function giving the two children of a node,
+// where the zero value of the node type (a nil pointer) stands for a missing node
+package shape
+
+// Height returns the number of edges on the longest path from the root down to a leaf, or -1 for an empty tree
+func Height[N comparable](root N, children func(N) (N, N)) int {
+	var none N
+	if root == none {
+		return -1
+	}
+
+	left, right := children(root)
+	return 1 + max(Height(left, children), Height(right, children))
+}
+
+// Diameter returns the number of edges on the longest path between any two nodes, or -1 for an empty tree
+func Diameter[N comparable](root N, children func(N) (N, N)) int {
+	diameter := -1
+
+	// recurse returns the height of the subtree, while checking the longest path that bends at each node
+	var recurse func(node N) int
+	recurse = func(node N) int {
+		var none N
+		if node == none {
+			return -1
+		}
+
+		left, right := children(node)
+		leftHeight, rightHeight := recurse(left), recurse(right)
+
+		diameter = max(diameter, leftHeight+rightHeight+2)
+		return 1 + max(leftHeight, rightHeight)
+	}
+
+	recurse(root)
+	return diameter
+}
+
+// LevelCounts returns the number of nodes on each level of the tree, starting with the root's level
+func LevelCounts[N comparable](root N, children func(N) (N, N)) []int {
+	var none N
+	counts := []int{}
+
+	level := []N{}
+	if root != none {
+		level = append(level, root)
+	}
+
+	for len(level) > 0 {
+		counts = append(counts, len(level))
+
+		next := []N{}
+		for _, node := range level {
+			left, right := children(node)
+			for _, child := range []N{left, right} {
+				if child != none {
+					next = append(next, child)
+				}
+			}
+		}
+		level = next
+	}
+
+	return counts
+}
+
+// MaxWidth returns the largest number of nodes on any single level of the tree
+func MaxWidth[N comparable](root N, children func(N) (N, N)) int {
+	width := 0
+	for _, count := range LevelCounts(root, children) {
+		width = max(width, count)
+	}
+	return width
+}
+
+// LeafCount returns the number of nodes with no children
+func LeafCount[N comparable](root N, children func(N) (N, N)) int {
+	var none N
+	if root == none {
+		return 0
+	}
+
+	left, right := children(root)
+	if left == none && right == none {
+		return 1
+	}
+	return LeafCount(left, children) + LeafCount(right, children)
+}
+
+// IsComplete tells you if every level of the tree is full, except possibly the last one, which is filled from the left
+// That is the case exactly when a breadth first walk never finds a node after the first missing child
+func IsComplete[N comparable](root N, children func(N) (N, N)) bool {
+	var none N
+	if root == none {
+		return true
+	}
+
+	queue := []N{root}
+	seenGap := false
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		left, right := children(node)
+		for _, child := range []N{left, right} {
+			if child == none {
+				seenGap = true
+				continue
+			}
+
+			if seenGap {
+				return false
+			}
+			queue = append(queue, child)
+		}
+	}
+
+	return true
+}
+
+// IsFull tells you if every node of the tree has either no children or two children
+func IsFull[N comparable](root N, children func(N) (N, N)) bool {
+	var none N
+	if root == none {
+		return true
+	}
+
+	left, right := children(root)
+	if (left == none) != (right == none) {
+		return false
+	}
+	return IsFull(left, children) && IsFull(right, children)
+}
+
+// IsPerfect tells you if the tree is full and all of its leaves are on the same level
+func IsPerfect[N comparable](root N, children func(N) (N, N)) bool {
+	// recurse returns the height of a perfect subtree, or false if the subtree is not perfect
+	var recurse func(node N) (int, bool)
+	recurse = func(node N) (int, bool) {
+		var none N
+		if node == none {
+			return -1, true
+		}
+
+		left, right := children(node)
+		leftHeight, leftPerfect := recurse(left)
+		if !leftPerfect {
+			return 0, false
+		}
+
+		rightHeight, rightPerfect := recurse(right)
+		if !rightPerfect || leftHeight != rightHeight {
+			return 0, false
+		}
+
+		return 1 + leftHeight, true
+	}
+
+	_, perfect := recurse(root)
+	return perfect
+}
+
+// IsHeightBalanced tells you if the heights of the two subtrees of every node differ by at most one
+func IsHeightBalanced[N comparable](root N, children func(N) (N, N)) bool {
+	// recurse returns the height of a balanced subtree, or false if the subtree is not balanced
+	var recurse func(node N) (int, bool)
+	recurse = func(node N) (int, bool) {
+		var none N
+		if node == none {
+			return -1, true
+		}
+
+		left, right := children(node)
+		leftHeight, leftBalanced := recurse(left)
+		if !leftBalanced {
+			return 0, false
+		}
+
+		rightHeight, rightBalanced := recurse(right)
+		if !rightBalanced || leftHeight-rightHeight > 1 || rightHeight-leftHeight > 1 {
+			return 0, false
+		}
+
+		return 1 + max(leftHeight, rightHeight), true
+	}
+
+	_, balanced := recurse(root)
+	return balanced
+}
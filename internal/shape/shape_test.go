@@ -0,0 +1,100 @@
+package shape
+
+import (
+	"slices"
+	"testing"
+)
+
+type node struct {
+	left, right *node
+}
+
+func children(n *node) (*node, *node) {
+	return n.left, n.right
+}
+
+// build makes a tree out of a level order description, where false marks a missing node
+// The children of the present nodes are read off in order, just like the [1,2,null,3] format
+func build(present ...bool) *node {
+	if len(present) == 0 || !present[0] {
+		return nil
+	}
+
+	root := &node{}
+	queue := []*node{root}
+	for i := 1; i < len(present); i += 2 {
+		parent := queue[0]
+		queue = queue[1:]
+
+		if present[i] {
+			parent.left = &node{}
+			queue = append(queue, parent.left)
+		}
+		if i+1 < len(present) && present[i+1] {
+			parent.right = &node{}
+			queue = append(queue, parent.right)
+		}
+	}
+	return root
+}
+
+func TestShape(t *testing.T) {
+	const o, x = true, false
+
+	tests := []struct {
+		name           string
+		root           *node
+		height         int
+		diameter       int
+		levelCounts    []int
+		maxWidth       int
+		leafCount      int
+		complete       bool
+		full           bool
+		perfect        bool
+		heightBalanced bool
+	}{
+		{"empty tree", build(), -1, -1, []int{}, 0, 0, true, true, true, true},
+		{"single node", build(o), 0, 0, []int{1}, 1, 1, true, true, true, true},
+		{"left child only", build(o, o), 1, 1, []int{1, 1}, 1, 1, true, false, false, true},
+		{"right child only", build(o, x, o), 1, 1, []int{1, 1}, 1, 1, false, false, false, true},
+		{"perfect tree", build(o, o, o, o, o, o, o), 2, 4, []int{1, 2, 4}, 4, 4, true, true, true, true},
+		{"complete tree", build(o, o, o, o, o, o), 2, 4, []int{1, 2, 3}, 3, 3, true, false, false, true},
+		{"full but not complete", build(o, o, o, x, x, o, o), 2, 3, []int{1, 2, 2}, 2, 3, false, true, false, true},
+		{"left chain", build(o, o, x, o, x, o), 3, 3, []int{1, 1, 1, 1}, 1, 1, false, false, false, false},
+		// the longest path runs between two leaves of the left subtree, and never reaches the root
+		{"diameter away from the root", build(o, o, x, o, o, o, x, x, o, o, x, x, o), 4, 6, []int{1, 1, 2, 2, 2}, 2, 2, false, false, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Height(test.root, children); got != test.height {
+				t.Errorf("Height() returned incorrect results, want: %v, got: %v", test.height, got)
+			}
+			if got := Diameter(test.root, children); got != test.diameter {
+				t.Errorf("Diameter() returned incorrect results, want: %v, got: %v", test.diameter, got)
+			}
+			if got := LevelCounts(test.root, children); !slices.Equal(got, test.levelCounts) {
+				t.Errorf("LevelCounts() returned incorrect results, want: %v, got: %v", test.levelCounts, got)
+			}
+			if got := MaxWidth(test.root, children); got != test.maxWidth {
+				t.Errorf("MaxWidth() returned incorrect results, want: %v, got: %v", test.maxWidth, got)
+			}
+			if got := LeafCount(test.root, children); got != test.leafCount {
+				t.Errorf("LeafCount() returned incorrect results, want: %v, got: %v", test.leafCount, got)
+			}
+			if got := IsComplete(test.root, children); got != test.complete {
+				t.Errorf("IsComplete() returned incorrect results, want: %v, got: %v", test.complete, got)
+			}
+			if got := IsFull(test.root, children); got != test.full {
+				t.Errorf("IsFull() returned incorrect results, want: %v, got: %v", test.full, got)
+			}
+			if got := IsPerfect(test.root, children); got != test.perfect {
+				t.Errorf("IsPerfect() returned incorrect results, want: %v, got: %v", test.perfect, got)
+			}
+			if got := IsHeightBalanced(test.root, children); got != test.heightBalanced {
+				t.Errorf("IsHeightBalanced() returned incorrect results, want: %v, got: %v", test.heightBalanced, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package walk: the traversal orders shared by the tree packages, as iterators over the nodes
+// Every function takes the root node and a function giving the two children of a node,
+// where the zero value of the node type (a nil pointer) stands for a missing node
+// Breaking out of the range loop stops the traversal immediately
+package walk
+
+import (
+	"iter"
+)
+
+// Order walks a tree in one of the traversal orders, like BFS and InOrder do
+type Order[N comparable] func(root N, children func(N) (N, N)) iter.Seq[N]
+
+// BFS returns an iterator over the nodes level by level, from left to right within each level
+func BFS[N comparable](root N, children func(N) (N, N)) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		var none N
+		if root == none {
+			return
+		}
+
+		queue := []N{root}
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+			if !yield(node) {
+				return
+			}
+
+			left, right := children(node)
+			if left != none {
+				queue = append(queue, left)
+			}
+			if right != none {
+				queue = append(queue, right)
+			}
+		}
+	}
+}
+
+// PreOrder returns an iterator over the nodes in pre-order (a node, then its left subtree, followed by its right subtree)
+func PreOrder[N comparable](root N, children func(N) (N, N)) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		var none N
+		if root == none {
+			return
+		}
+
+		stack := []N{root}
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(node) {
+				return
+			}
+
+			// first right, then left so that they are popped in the correct order
+			left, right := children(node)
+			if right != none {
+				stack = append(stack, right)
+			}
+			if left != none {
+				stack = append(stack, left)
+			}
+		}
+	}
+}
+
+// InOrder returns an iterator over the nodes in in-order (a node's left subtree, then the node itself, followed by its right subtree)
+func InOrder[N comparable](root N, children func(N) (N, N)) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		var none N
+		runner := root
+		stack := []N{}
+
+		for runner != none || len(stack) > 0 {
+			if runner != none {
+				stack = append(stack, runner)
+				runner, _ = children(runner)
+				continue
+			}
+
+			visit := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(visit) {
+				return
+			}
+			_, runner = children(visit)
+		}
+	}
+}
+
+// PostOrder returns an iterator over the nodes in post-order (a node's left subtree, then its right subtree, finally the node itself)
+func PostOrder[N comparable](root N, children func(N) (N, N)) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		var none, lastVisited N
+		runner := root
+		stack := []N{}
+
+		for runner != none || len(stack) > 0 {
+			if runner != none {
+				stack = append(stack, runner)
+				runner, _ = children(runner)
+				continue
+			}
+
+			potentialVisit := stack[len(stack)-1]
+			if _, right := children(potentialVisit); right != none && right != lastVisited {
+				runner = right
+				continue
+			}
+
+			lastVisited = potentialVisit
+			stack = stack[:len(stack)-1]
+			if !yield(lastVisited) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package walk
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+type node struct {
+	label       int
+	left, right *node
+}
+
+func children(n *node) (*node, *node) {
+	return n.left, n.right
+}
+
+func labels(seq iter.Seq[*node]) []int {
+	got := []int{}
+	for n := range seq {
+		got = append(got, n.label)
+	}
+	return got
+}
+
+func TestWalk(t *testing.T) {
+	//          1
+	//        /   \
+	//       2     3
+	//        \   /
+	//         4 5
+	//        /
+	//       6
+	tree := &node{1, &node{2, nil, &node{4, &node{6, nil, nil}, nil}}, &node{3, &node{5, nil, nil}, nil}}
+	chain := &node{1, nil, &node{2, nil, &node{3, nil, nil}}}
+
+	tests := []struct {
+		name  string
+		walk  Order[*node]
+		tree  []int
+		chain []int
+	}{
+		{"BFS", BFS[*node], []int{1, 2, 3, 4, 5, 6}, []int{1, 2, 3}},
+		{"PreOrder", PreOrder[*node], []int{1, 2, 4, 6, 3, 5}, []int{1, 2, 3}},
+		{"InOrder", InOrder[*node], []int{2, 6, 4, 1, 5, 3}, []int{1, 2, 3}},
+		{"PostOrder", PostOrder[*node], []int{6, 4, 2, 5, 3, 1}, []int{3, 2, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := labels(test.walk(nil, children)); len(got) != 0 {
+				t.Errorf("%v() of an empty tree should not yield any nodes, got: %v", test.name, got)
+			}
+
+			if got := labels(test.walk(tree, children)); !slices.Equal(got, test.tree) {
+				t.Errorf("%v() returned incorrect results, want: %v, got: %v", test.name, test.tree, got)
+			}
+
+			if got := labels(test.walk(chain, children)); !slices.Equal(got, test.chain) {
+				t.Errorf("%v() of a right chain returned incorrect results, want: %v, got: %v", test.name, test.chain, got)
+			}
+
+			// breaking out of the loop should stop the walk right away
+			visited := []int{}
+			for n := range test.walk(tree, children) {
+				visited = append(visited, n.label)
+				if len(visited) == 2 {
+					break
+				}
+			}
+			if !slices.Equal(visited, test.tree[:2]) {
+				t.Errorf("%v() returned incorrect results after breaking early, want: %v, got: %v", test.name, test.tree[:2], visited)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package rbtreelib
+
+import "fmt"
+
+// CheckInvariants verifies that the red-black tree is a valid binary search tree which holds all the red-black properties:
+// the root is black, no red node has a red child, and every path from a node down to its nil leaves has the same number of black nodes
+// It returns an error describing the first violation it finds, or nil if the tree is valid
+func (rbt *RedBlackTree[T]) CheckInvariants() error {
+	if rbt.IsNil() {
+		return treeNilError
+	}
+
+	if rbt.IsEmpty() {
+		if rbt.count != 0 {
+			return fmt.Errorf("the red-black tree is empty but has a count of %v", rbt.count)
+		}
+		return nil
+	}
+
+	compare, err := rbt.comparator()
+	if err != nil {
+		return err
+	}
+
+	if rbt.root.parent != nil {
+		return fmt.Errorf("the root %v has a parent: %v", rbt.root, rbt.root.parent)
+	}
+
+	if rbt.root.isRed() {
+		return fmt.Errorf("the root %v is red", rbt.root)
+	}
+
+	count := 0
+	var prev *Node[T]
+
+	// check returns the black height of the subtree rooted at the node
+	var check func(node *Node[T]) (int, error)
+	check = func(node *Node[T]) (int, error) {
+		if node == nil {
+			return 1, nil
+		}
+
+		for _, child := range []*Node[T]{node.left, node.right} {
+			if child == nil {
+				continue
+			}
+			if child.parent != node {
+				return 0, fmt.Errorf("the node %v does not point back to its parent %v", child, node)
+			}
+			if node.isRed() && child.isRed() {
+				return 0, fmt.Errorf("the red node %v has a red child %v", node, child)
+			}
+		}
+
+		leftHeight, err2 := check(node.left)
+		if err2 != nil {
+			return 0, err2
+		}
+
+		// in-order visit, the values should be strictly increasing
+		if prev != nil && compare(prev.data, node.data) >= 0 {
+			return 0, fmt.Errorf("the values %v and %v are out of order", prev, node)
+		}
+		prev = node
+		count += 1
+
+		rightHeight, err2 := check(node.right)
+		if err2 != nil {
+			return 0, err2
+		}
+
+		if leftHeight != rightHeight {
+			return 0, fmt.Errorf("the subtrees of the node %v have different black heights: %v and %v", node, leftHeight, rightHeight)
+		}
+
+		if node.isRed() {
+			return leftHeight, nil
+		}
+		return leftHeight + 1, nil
+	}
+
+	_, err = check(rbt.root)
+	if err != nil {
+		return err
+	}
+
+	if count != rbt.count {
+		return fmt.Errorf("the red-black tree has %v nodes but a count of %v", count, rbt.count)
+	}
+
+	return nil
+}
+
+// BlackHeight returns the number of black nodes on any path from the root down to a leaf (not counting the nil leaves)
+func (rbt *RedBlackTree[T]) BlackHeight() (int, error) {
+	if rbt.IsNil() {
+		return invalidCount, treeNilError
+	}
+
+	height := 0
+	for runner := rbt.root; runner != nil; runner = runner.left {
+		if !runner.isRed() {
+			height += 1
+		}
+	}
+
+	return height, nil
+}
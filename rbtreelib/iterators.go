@@ -0,0 +1,56 @@
+package rbtreelib
+
+import (
+	"iter"
+
+	"github.com/pluckynumbat/go-tree/internal/walk"
+)
+
+// All returns an iterator over the values of the red-black tree, in ascending order
+func (rbt *RedBlackTree[T]) All() iter.Seq[T] {
+	return rbt.InOrder()
+}
+
+// BFS returns an iterator over the values of the red-black tree using Breadth First Search
+// Breaking out of the range loop stops the traversal immediately
+func (rbt *RedBlackTree[T]) BFS() iter.Seq[T] {
+	return rbt.values(walk.BFS[*Node[T]])
+}
+
+// PreOrder returns an iterator over the values of the red-black tree using Depth First Search
+// In a pre-order manner (visit a node, then its left subtree, followed by its right subtree)
+func (rbt *RedBlackTree[T]) PreOrder() iter.Seq[T] {
+	return rbt.values(walk.PreOrder[*Node[T]])
+}
+
+// InOrder returns an iterator over the values of the red-black tree using Depth First Search
+// In an in-order manner (visit a node's left subtree, then the node itself, followed by its right subtree)
+func (rbt *RedBlackTree[T]) InOrder() iter.Seq[T] {
+	return rbt.values(walk.InOrder[*Node[T]])
+}
+
+// PostOrder returns an iterator over the values of the red-black tree using Depth First Search
+// In a post-order manner (visit a node's left subtree, then the node's right subtree, finally the node itself)
+func (rbt *RedBlackTree[T]) PostOrder() iter.Seq[T] {
+	return rbt.values(walk.PostOrder[*Node[T]])
+}
+
+// values walks the red-black tree in the given order, yielding the value of every node it visits
+func (rbt *RedBlackTree[T]) values(order walk.Order[*Node[T]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if rbt.IsEmpty() {
+			return
+		}
+
+		for node := range order(rbt.root, (*Node[T]).children) {
+			if !yield(node.data) {
+				return
+			}
+		}
+	}
+}
+
+// children returns both children of the node, which is how the shared traversals walk the tree
+func (node *Node[T]) children() (*Node[T], *Node[T]) {
+	return node.left, node.right
+}
@@ -0,0 +1,541 @@
+// Package rbtreelib: Basic Red-Black Tree Stuff
+package rbtreelib
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+
+	"github.com/pluckynumbat/go-tree/internal/ordering"
+)
+
+const invalidCount = -1
+
+var nodeNilError = fmt.Errorf("the node is nil")
+var treeNilError = fmt.Errorf("the red-black tree is nil")
+var treeEmptyError = fmt.Errorf("the red-black tree is empty")
+var noComparatorError = fmt.Errorf("the red-black tree has no comparator for its element type")
+
+// duplicateElementError is a custom error raised when an element already present in the tree is attempted to be inserted
+type duplicateElementError[T any] struct {
+	value T
+}
+
+// duplicateElementError's implementation of the Error interface
+func (err duplicateElementError[T]) Error() string {
+	return fmt.Sprintf("the red-black tree already has the value attempting to be inserted: %v", err.value)
+}
+
+// elementNotFoundError is a custom error raised when an element that is not present in the tree is attempted to be deleted
+type elementNotFoundError[T any] struct {
+	value T
+}
+
+// elementNotFoundError's implementation of the Error interface
+func (err elementNotFoundError[T]) Error() string {
+	return fmt.Sprintf("the red-black tree does not have the value attempting to be deleted: %v", err.value)
+}
+
+// Color is the color of a node in the red-black tree, nil children count as black
+type Color bool
+
+const (
+	Black Color = false
+	Red   Color = true
+)
+
+// Color's implementation of the fmt.Stringer interface
+func (c Color) String() string {
+	if c == Red {
+		return "red"
+	}
+	return "black"
+}
+
+// Node is the basic unit of the red-black tree, and contains data which can be of any type the tree knows how to compare
+type Node[T any] struct {
+	data   T
+	parent *Node[T]
+	left   *Node[T]
+	right  *Node[T]
+	color  Color
+}
+
+// Node's implementation of the fmt.Stringer interface
+func (node *Node[T]) String() string {
+	if node == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%v", node.data)
+}
+
+// Parent is used to get a pointer to the parent node of a given node
+func (node *Node[T]) Parent() (*Node[T], error) {
+	if node == nil {
+		return nil, nodeNilError
+	}
+	return node.parent, nil
+}
+
+// LeftChild is used to get a pointer to the left child of a given node
+func (node *Node[T]) LeftChild() (*Node[T], error) {
+	if node == nil {
+		return nil, nodeNilError
+	}
+	return node.left, nil
+}
+
+// RightChild is used to get a pointer to the right child of a given node
+func (node *Node[T]) RightChild() (*Node[T], error) {
+	if node == nil {
+		return nil, nodeNilError
+	}
+	return node.right, nil
+}
+
+// Color is used to get the color of a given node
+func (node *Node[T]) Color() (Color, error) {
+	if node == nil {
+		return Black, nodeNilError
+	}
+	return node.color, nil
+}
+
+// isRed tells you if the node is red, nil nodes are black
+func (node *Node[T]) isRed() bool {
+	return node != nil && node.color == Red
+}
+
+// RedBlackTree is a self balancing binary search tree that needs fewer rotations than an AVL tree on updates
+// The zero value is ready to use for element types whose underlying type is ordered (integers, floats and strings),
+// other element types need a tree created using NewWithComparator
+type RedBlackTree[T any] struct {
+	root    *Node[T]
+	count   int
+	compare func(a, b T) int
+}
+
+// New returns an empty red-black tree that orders its elements using cmp.Compare
+func New[T cmp.Ordered]() *RedBlackTree[T] {
+	return &RedBlackTree[T]{compare: cmp.Compare[T]}
+}
+
+// NewWithComparator returns an empty red-black tree that orders its elements using the given comparator
+// The comparator should return a negative number when a < b, zero when a == b and a positive number when a > b
+func NewWithComparator[T any](compare func(a, b T) int) *RedBlackTree[T] {
+	return &RedBlackTree[T]{compare: compare}
+}
+
+// ConstructFromValues is a helper function to insert all the given values (in the order that they are provided) into a red-black tree
+func ConstructFromValues[T cmp.Ordered](values ...T) (*RedBlackTree[T], error) {
+	rbt := New[T]()
+
+	for _, val := range values {
+		err := rbt.Insert(val)
+		if err != nil {
+			return nil, fmt.Errorf("construct from values failed with error: %v", err)
+		}
+	}
+
+	return rbt, nil
+}
+
+// comparator returns the function used to order the elements of the tree,
+// falling back to the natural ordering of the element type when the tree was not given one
+func (rbt *RedBlackTree[T]) comparator() (func(a, b T) int, error) {
+	if rbt.compare != nil {
+		return rbt.compare, nil
+	}
+
+	compare := ordering.Comparator[T]()
+	if compare == nil {
+		return nil, noComparatorError
+	}
+
+	return compare, nil
+}
+
+// IsNil tells you if the pointer to the red-black tree is nil
+func (rbt *RedBlackTree[T]) IsNil() bool {
+	return rbt == nil
+}
+
+// IsEmpty tells you if the red-black tree is empty
+func (rbt *RedBlackTree[T]) IsEmpty() bool {
+	return rbt.IsNil() || rbt.root == nil
+}
+
+// Root returns a pointer to the root of a red-black tree
+func (rbt *RedBlackTree[T]) Root() *Node[T] {
+	if rbt.IsNil() {
+		return nil
+	}
+	return rbt.root
+}
+
+// Count returns the number of elements in a red-black tree
+func (rbt *RedBlackTree[T]) Count() (int, error) {
+	if rbt.IsNil() {
+		return invalidCount, treeNilError
+	}
+	return rbt.count, nil
+}
+
+// Insert will add a new value to the red-black tree at the correct position, and then restore the red-black properties
+func (rbt *RedBlackTree[T]) Insert(value T) error {
+	if rbt.IsNil() {
+		return treeNilError
+	}
+
+	compare, err := rbt.comparator()
+	if err != nil {
+		return err
+	}
+
+	var parent *Node[T]
+	result := 0
+	runner := rbt.root
+
+	for runner != nil {
+		parent = runner
+		result = compare(value, runner.data)
+
+		if result == 0 { // the value is already present
+			return duplicateElementError[T]{value}
+		}
+
+		if result < 0 {
+			runner = runner.left
+		} else {
+			runner = runner.right
+		}
+	}
+
+	node := &Node[T]{data: value, parent: parent, color: Red}
+	switch {
+	case parent == nil:
+		rbt.root = node
+	case result < 0:
+		parent.left = node
+	default:
+		parent.right = node
+	}
+
+	rbt.count += 1
+	rbt.insertFixup(node)
+	return nil
+}
+
+// insertFixup recolors and rotates nodes till a newly inserted red node no longer has a red parent
+func (rbt *RedBlackTree[T]) insertFixup(node *Node[T]) {
+	for node.parent.isRed() {
+		parent := node.parent
+		grandparent := parent.parent // a red parent is never the root, so this is not nil
+
+		if parent == grandparent.left {
+			uncle := grandparent.right
+
+			if uncle.isRed() { // push the blackness down from the grandparent, and continue from there
+				parent.color, uncle.color, grandparent.color = Black, Black, Red
+				node = grandparent
+				continue
+			}
+
+			if node == parent.right { // turn the inner grandchild into an outer one
+				node = parent
+				rbt.rotateLeft(node)
+				parent = node.parent
+			}
+
+			parent.color, grandparent.color = Black, Red
+			rbt.rotateRight(grandparent)
+		} else {
+			uncle := grandparent.left
+
+			if uncle.isRed() {
+				parent.color, uncle.color, grandparent.color = Black, Black, Red
+				node = grandparent
+				continue
+			}
+
+			if node == parent.left {
+				node = parent
+				rbt.rotateRight(node)
+				parent = node.parent
+			}
+
+			parent.color, grandparent.color = Black, Red
+			rbt.rotateLeft(grandparent)
+		}
+	}
+
+	rbt.root.color = Black
+}
+
+// Search looks for a given value in the red-black tree, and tell you whether that value is present in the tree or not
+func (rbt *RedBlackTree[T]) Search(val T) (bool, error) {
+	if rbt.IsNil() {
+		return false, treeNilError
+	}
+
+	if rbt.IsEmpty() {
+		return false, treeEmptyError
+	}
+
+	node, err := rbt.findNode(val)
+	if err != nil {
+		return false, err
+	}
+
+	return node != nil, nil
+}
+
+// findNode returns a pointer to the node containing the given value, or nil if the value is not present in the tree
+func (rbt *RedBlackTree[T]) findNode(val T) (*Node[T], error) {
+	compare, err := rbt.comparator()
+	if err != nil {
+		return nil, err
+	}
+
+	runner := rbt.root
+
+	for runner != nil {
+		result := compare(val, runner.data)
+
+		if result == 0 {
+			return runner, nil
+		}
+
+		if result < 0 {
+			runner = runner.left
+		} else {
+			runner = runner.right
+		}
+	}
+
+	return nil, nil
+}
+
+// Delete removes the given value from the red-black tree, and then restores the red-black properties
+func (rbt *RedBlackTree[T]) Delete(value T) error {
+	if rbt.IsNil() {
+		return treeNilError
+	}
+
+	if rbt.IsEmpty() {
+		return treeEmptyError
+	}
+
+	node, err := rbt.findNode(value)
+	if err != nil {
+		return err
+	}
+
+	if node == nil {
+		return elementNotFoundError[T]{value}
+	}
+
+	// the node that takes the place of the one that is physically removed (may be nil), and its parent
+	var replacement, replacementParent *Node[T]
+	removedColor := node.color
+
+	switch {
+	case node.left == nil:
+		replacement, replacementParent = node.right, node.parent
+		rbt.transplant(node, node.right)
+
+	case node.right == nil:
+		replacement, replacementParent = node.left, node.parent
+		rbt.transplant(node, node.left)
+
+	default: // two children, the successor moves into the node's place and takes its color
+		successor := node.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+
+		removedColor = successor.color
+		replacement, replacementParent = successor.right, successor
+
+		if successor.parent != node {
+			replacementParent = successor.parent
+			rbt.transplant(successor, successor.right)
+			successor.right = node.right
+			successor.right.parent = successor
+		}
+
+		rbt.transplant(node, successor)
+		successor.left = node.left
+		successor.left.parent = successor
+		successor.color = node.color
+	}
+
+	node.parent, node.left, node.right = nil, nil, nil
+	rbt.count -= 1
+
+	// removing a red node never breaks the red-black properties
+	if removedColor == Black {
+		rbt.deleteFixup(replacement, replacementParent)
+	}
+
+	return nil
+}
+
+// deleteFixup recolors and rotates nodes till the extra blackness left behind by a removed black node is absorbed
+// The node can be nil, which is why its parent is passed in separately
+func (rbt *RedBlackTree[T]) deleteFixup(node, parent *Node[T]) {
+	for node != rbt.root && !node.isRed() {
+		// the sibling is never nil here, since the node's side is short of one black node
+		if node == parent.left {
+			sibling := parent.right
+
+			if sibling.isRed() { // make the sibling black
+				sibling.color, parent.color = Black, Red
+				rbt.rotateLeft(parent)
+				sibling = parent.right
+			}
+
+			if !sibling.left.isRed() && !sibling.right.isRed() { // move the extra blackness up
+				sibling.color = Red
+				node, parent = parent, parent.parent
+				continue
+			}
+
+			if !sibling.right.isRed() { // make the sibling's outer child red
+				sibling.left.color, sibling.color = Black, Red
+				rbt.rotateRight(sibling)
+				sibling = parent.right
+			}
+
+			sibling.color, parent.color, sibling.right.color = parent.color, Black, Black
+			rbt.rotateLeft(parent)
+			node, parent = rbt.root, nil
+		} else {
+			sibling := parent.left
+
+			if sibling.isRed() {
+				sibling.color, parent.color = Black, Red
+				rbt.rotateRight(parent)
+				sibling = parent.left
+			}
+
+			if !sibling.left.isRed() && !sibling.right.isRed() {
+				sibling.color = Red
+				node, parent = parent, parent.parent
+				continue
+			}
+
+			if !sibling.left.isRed() {
+				sibling.right.color, sibling.color = Black, Red
+				rbt.rotateLeft(sibling)
+				sibling = parent.left
+			}
+
+			sibling.color, parent.color, sibling.left.color = parent.color, Black, Black
+			rbt.rotateRight(parent)
+			node, parent = rbt.root, nil
+		}
+	}
+
+	if node != nil {
+		node.color = Black
+	}
+}
+
+// transplant replaces the subtree rooted at the old node with the subtree rooted at the replacement node (which can be nil)
+func (rbt *RedBlackTree[T]) transplant(old, replacement *Node[T]) {
+	switch {
+	case old.parent == nil:
+		rbt.root = replacement
+	case old.parent.left == old:
+		old.parent.left = replacement
+	default:
+		old.parent.right = replacement
+	}
+
+	if replacement != nil {
+		replacement.parent = old.parent
+	}
+}
+
+// rotateLeft makes the right child of the node the new root of its subtree
+func (rbt *RedBlackTree[T]) rotateLeft(node *Node[T]) {
+	pivot := node.right
+
+	node.right = pivot.left
+	if pivot.left != nil {
+		pivot.left.parent = node
+	}
+
+	rbt.transplant(node, pivot)
+	pivot.left = node
+	node.parent = pivot
+}
+
+// rotateRight makes the left child of the node the new root of its subtree
+func (rbt *RedBlackTree[T]) rotateRight(node *Node[T]) {
+	pivot := node.left
+
+	node.left = pivot.right
+	if pivot.right != nil {
+		pivot.right.parent = node
+	}
+
+	rbt.transplant(node, pivot)
+	pivot.right = node
+	node.parent = pivot
+}
+
+// TraverseBFS returns a string that represents the traversal order of nodes using Breadth First Search
+func (rbt *RedBlackTree[T]) TraverseBFS() (string, error) {
+	return rbt.traverse(rbt.BFS)
+}
+
+// TraverseDFSInOrder returns a string that represents the traversal order of nodes using Depth First Search
+// In an in-order manner (visit a node's left subtree, then the node itself, followed by its right subtree)
+func (rbt *RedBlackTree[T]) TraverseDFSInOrder() (string, error) {
+	return rbt.traverse(rbt.InOrder)
+}
+
+// TraverseDFSPreOrder returns a string that represents the traversal order of nodes using Depth First Search
+// In a pre-order manner (visit a node, then its left subtree, followed by its right subtree)
+func (rbt *RedBlackTree[T]) TraverseDFSPreOrder() (string, error) {
+	return rbt.traverse(rbt.PreOrder)
+}
+
+// TraverseDFSPostOrder returns a string that represents the traversal order of nodes using Depth First Search
+// In a post-order manner (visit a node's left subtree, then the node's right subtree, finally the node itself)
+func (rbt *RedBlackTree[T]) TraverseDFSPostOrder() (string, error) {
+	return rbt.traverse(rbt.PostOrder)
+}
+
+// traverse builds the traversal string from one of the tree's iterators
+func (rbt *RedBlackTree[T]) traverse(order func() iter.Seq[T]) (string, error) {
+	if rbt.IsNil() {
+		return "", treeNilError
+	}
+
+	if rbt.IsEmpty() {
+		return "", treeEmptyError
+	}
+
+	treeStr := ""
+	for val := range order() {
+		treeStr += fmt.Sprintf("-(%v)-", val)
+	}
+	return treeStr, nil
+}
+
+// ConstructOrderedSlice collects all the elements in the red-black tree in an ordered manner, and returns them in a slice
+func (rbt *RedBlackTree[T]) ConstructOrderedSlice() ([]T, error) {
+	if rbt.IsNil() {
+		return nil, treeNilError
+	}
+
+	result := make([]T, 0, rbt.count)
+	for val := range rbt.InOrder() {
+		result = append(result, val)
+	}
+
+	return result, nil
+}
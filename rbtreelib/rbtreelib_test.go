@@ -0,0 +1,452 @@
+package rbtreelib
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// colorsBFS returns the colors of the nodes of the tree in breadth first order, as a string of R and B characters
+func colorsBFS[T any](rbt *RedBlackTree[T]) string {
+	result := ""
+	queue := []*Node[T]{rbt.root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node == nil {
+			continue
+		}
+		if node.isRed() {
+			result += "R"
+		} else {
+			result += "B"
+		}
+		queue = append(queue, node.left, node.right)
+	}
+	return result
+}
+
+func TestNodeGetters(t *testing.T) {
+	var n1 *Node[int]
+	n2 := &Node[int]{data: 1, color: Black}
+	n3 := &Node[int]{data: 2, parent: n2, color: Red}
+	n2.left = n3
+
+	_, err := n1.Parent()
+	if !errors.Is(err, nodeNilError) {
+		t.Errorf("Parent() on a nil node should have failed with error: %v, got: %v", nodeNilError, err)
+	}
+
+	_, err = n1.Color()
+	if !errors.Is(err, nodeNilError) {
+		t.Errorf("Color() on a nil node should have failed with error: %v, got: %v", nodeNilError, err)
+	}
+
+	tests := []struct {
+		name       string
+		node       *Node[int]
+		wantParent *Node[int]
+		wantLeft   *Node[int]
+		wantRight  *Node[int]
+		wantColor  Color
+		wantStr    string
+	}{
+		{"black root", n2, nil, n3, nil, Black, "1"},
+		{"red leaf", n3, n2, nil, nil, Red, "2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parent, err2 := test.node.Parent()
+			if err2 != nil || parent != test.wantParent {
+				t.Errorf("Parent() returned incorrect results, want: %v, got: %v (error: %v)", test.wantParent, parent, err2)
+			}
+
+			left, err2 := test.node.LeftChild()
+			if err2 != nil || left != test.wantLeft {
+				t.Errorf("LeftChild() returned incorrect results, want: %v, got: %v (error: %v)", test.wantLeft, left, err2)
+			}
+
+			right, err2 := test.node.RightChild()
+			if err2 != nil || right != test.wantRight {
+				t.Errorf("RightChild() returned incorrect results, want: %v, got: %v (error: %v)", test.wantRight, right, err2)
+			}
+
+			color, err2 := test.node.Color()
+			if err2 != nil || color != test.wantColor {
+				t.Errorf("Color() returned incorrect results, want: %v, got: %v (error: %v)", test.wantColor, color, err2)
+			}
+
+			if got := test.node.String(); got != test.wantStr {
+				t.Errorf("String() returned incorrect results, want: %v, got: %v", test.wantStr, got)
+			}
+		})
+	}
+}
+
+func TestInsert(t *testing.T) {
+	var rbt *RedBlackTree[int]
+	err := rbt.Insert(1)
+	if !errors.Is(err, treeNilError) {
+		t.Fatalf("Insert() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	rbt = &RedBlackTree[int]{}
+
+	tests := []struct {
+		name       string
+		val        int
+		wantBFS    string
+		wantColors string
+	}{
+		{"empty tree", 1, "-(1)-", "B"},
+		{"red child", 2, "-(1)--(2)-", "BR"},
+		{"rotation", 3, "-(2)--(1)--(3)-", "BRR"},
+		{"recolor", 4, "-(2)--(1)--(3)--(4)-", "BBBR"},
+		{"rotation below the root", 5, "-(2)--(1)--(4)--(3)--(5)-", "BBBRR"},
+		{"recolor below the root", 6, "-(2)--(1)--(4)--(3)--(5)--(6)-", "BBRBBR"},
+		{"rotation after recolor", 7, "-(2)--(1)--(4)--(3)--(6)--(5)--(7)-", "BBRBBRR"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err2 := rbt.Insert(test.val)
+			if err2 != nil {
+				t.Fatalf("Insert() failed with error: %v", err2)
+			}
+
+			gotBFS, err2 := rbt.TraverseBFS()
+			if err2 != nil {
+				t.Fatalf("TraverseBFS() failed with error: %v", err2)
+			} else if gotBFS != test.wantBFS {
+				t.Errorf("Insert() gave incorrect results, want: %v, got: %v", test.wantBFS, gotBFS)
+			}
+
+			if gotColors := colorsBFS(rbt); gotColors != test.wantColors {
+				t.Errorf("Insert() left incorrect colors, want: %v, got: %v", test.wantColors, gotColors)
+			}
+
+			err2 = rbt.CheckInvariants()
+			if err2 != nil {
+				t.Errorf("CheckInvariants() failed with error: %v", err2)
+			}
+		})
+	}
+
+	err = rbt.Insert(4)
+	if err == nil {
+		t.Fatalf("Insert() using a value already present in the tree should have failed")
+	} else {
+		fmt.Println(err)
+	}
+
+	t.Run("struct ordered by a field", func(t *testing.T) {
+		type event struct {
+			at   int
+			name string
+		}
+
+		events := NewWithComparator(func(a, b event) int { return a.at - b.at })
+		for _, e := range []event{{30, "c"}, {10, "a"}, {20, "b"}} {
+			err2 := events.Insert(e)
+			if err2 != nil {
+				t.Fatalf("Insert() failed with error: %v", err2)
+			}
+		}
+
+		want := "-({10 a})--({20 b})--({30 c})-"
+		got, err2 := events.TraverseDFSInOrder()
+		if err2 != nil {
+			t.Fatalf("TraverseDFSInOrder() failed with error: %v", err2)
+		} else if got != want {
+			t.Errorf("TraverseDFSInOrder() returned incorrect results, want: %v, got: %v", want, got)
+		}
+
+		err2 = (&RedBlackTree[event]{}).Insert(event{})
+		if !errors.Is(err2, noComparatorError) {
+			t.Errorf("Insert() should have failed with error: %v, got: %v", noComparatorError, err2)
+		}
+	})
+}
+
+func TestSearch(t *testing.T) {
+	var rbt *RedBlackTree[string]
+	_, err := rbt.Search("a")
+	if !errors.Is(err, treeNilError) {
+		t.Fatalf("Search() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	}
+
+	rbt = New[string]()
+	_, err = rbt.Search("a")
+	if !errors.Is(err, treeEmptyError) {
+		t.Fatalf("Search() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	}
+
+	rbt, err = ConstructFromValues("m", "c", "x", "a", "e")
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		val  string
+		want bool
+	}{
+		{"root", "m", true},
+		{"leaf", "e", true},
+		{"absent value", "d", false},
+		{"above the maximum", "z", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err2 := rbt.Search(test.val)
+			if err2 != nil {
+				t.Fatalf("Search() failed with error: %v", err2)
+			} else if got != test.want {
+				t.Errorf("Search() returned incorrect results, want: %v, got: %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var rbt *RedBlackTree[int]
+	err := rbt.Delete(1)
+	if !errors.Is(err, treeNilError) {
+		t.Fatalf("Delete() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	}
+
+	rbt = New[int]()
+	err = rbt.Delete(1)
+	if !errors.Is(err, treeEmptyError) {
+		t.Fatalf("Delete() on an empty tree should have failed with error: %v, got: %v", treeEmptyError, err)
+	}
+
+	rbt, err = ConstructFromValues(1, 2, 3, 4, 5, 6, 7)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	err = rbt.Delete(8)
+	if !errors.Is(err, elementNotFoundError[int]{8}) {
+		t.Fatalf("Delete() of an absent value should have failed with error: %v, got: %v", elementNotFoundError[int]{8}, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	tests := []struct {
+		name       string
+		val        int
+		wantBFS    string
+		wantColors string
+	}{
+		{"red leaf", 7, "-(2)--(1)--(4)--(3)--(6)--(5)-", "BBRBBR"},
+		{"black node with a red child", 6, "-(2)--(1)--(4)--(3)--(5)-", "BBRBB"},
+		{"black leaf with black sibling", 1, "-(4)--(2)--(5)--(3)-", "BBBR"},
+		{"root", 4, "-(3)--(2)--(5)-", "BBB"},
+		{"black leaf", 5, "-(3)--(2)-", "BR"},
+		{"root with a red child", 3, "-(2)-", "B"},
+		{"last value", 2, "", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err2 := rbt.Delete(test.val)
+			if err2 != nil {
+				t.Fatalf("Delete() failed with error: %v", err2)
+			}
+
+			gotBFS, err2 := rbt.TraverseBFS()
+			if err2 != nil && !errors.Is(err2, treeEmptyError) {
+				t.Fatalf("TraverseBFS() failed with error: %v", err2)
+			} else if gotBFS != test.wantBFS {
+				t.Errorf("Delete() gave incorrect results, want: %v, got: %v", test.wantBFS, gotBFS)
+			}
+
+			if gotColors := colorsBFS(rbt); gotColors != test.wantColors {
+				t.Errorf("Delete() left incorrect colors, want: %v, got: %v", test.wantColors, gotColors)
+			}
+
+			err2 = rbt.CheckInvariants()
+			if err2 != nil {
+				t.Errorf("CheckInvariants() failed with error: %v", err2)
+			}
+		})
+	}
+}
+
+func TestRandomUpdates(t *testing.T) {
+	rng := rand.New(rand.NewPCG(17, 19))
+	rbt := New[int]()
+	present := make([]int, 0, 500)
+
+	for step := 0; step < 5000; step++ {
+		val := rng.IntN(500)
+		idx, found := slices.BinarySearch(present, val)
+
+		if found {
+			err := rbt.Delete(val)
+			if err != nil {
+				t.Fatalf("Delete() failed with error: %v", err)
+			}
+			present = slices.Delete(present, idx, idx+1)
+		} else {
+			err := rbt.Insert(val)
+			if err != nil {
+				t.Fatalf("Insert() failed with error: %v", err)
+			}
+			present = slices.Insert(present, idx, val)
+		}
+
+		err := rbt.CheckInvariants()
+		if err != nil {
+			t.Fatalf("CheckInvariants() failed after step %v with error: %v", step, err)
+		}
+	}
+
+	got, err := rbt.ConstructOrderedSlice()
+	if err != nil {
+		t.Fatalf("ConstructOrderedSlice() failed with error: %v", err)
+	} else if !slices.Equal(got, present) {
+		t.Errorf("ConstructOrderedSlice() returned incorrect results, want: %v, got: %v", present, got)
+	}
+
+	cnt, err := rbt.Count()
+	if err != nil {
+		t.Fatalf("Count() failed with error: %v", err)
+	} else if cnt != len(present) {
+		t.Errorf("Count() returned incorrect results, want: %v, got: %v", len(present), cnt)
+	}
+}
+
+func TestCheckInvariants(t *testing.T) {
+	var rbt *RedBlackTree[int]
+	err := rbt.CheckInvariants()
+	if !errors.Is(err, treeNilError) {
+		t.Fatalf("CheckInvariants() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	}
+
+	err = New[int]().CheckInvariants()
+	if err != nil {
+		t.Fatalf("CheckInvariants() on an empty tree failed with error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		corrupt func(rbt *RedBlackTree[int])
+	}{
+		{"red root", func(rbt *RedBlackTree[int]) { rbt.root.color = Red }},
+		{"red node with a red child", func(rbt *RedBlackTree[int]) { rbt.root.right.right.color = Red }},
+		{"unequal black heights", func(rbt *RedBlackTree[int]) { rbt.root.left.color = Red }},
+		{"values out of order", func(rbt *RedBlackTree[int]) { rbt.root.left.data = 5 }},
+		{"broken parent pointer", func(rbt *RedBlackTree[int]) { rbt.root.left.parent = nil }},
+		{"incorrect count", func(rbt *RedBlackTree[int]) { rbt.count = 2 }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// 2B(1B, 4R(3B, 6B(5R, 7R)))
+			rbt, err2 := ConstructFromValues(1, 2, 3, 4, 5, 6, 7)
+			if err2 != nil {
+				t.Fatalf("ConstructFromValues() failed with error: %v", err2)
+			}
+
+			test.corrupt(rbt)
+			err2 = rbt.CheckInvariants()
+			if err2 == nil {
+				t.Errorf("CheckInvariants() should have failed")
+			} else {
+				fmt.Println(err2)
+			}
+		})
+	}
+}
+
+func TestBlackHeight(t *testing.T) {
+	var rbt *RedBlackTree[int]
+	_, err := rbt.BlackHeight()
+	if !errors.Is(err, treeNilError) {
+		t.Fatalf("BlackHeight() on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+	}
+
+	tests := []struct {
+		name  string
+		input []int
+		want  int
+	}{
+		{"empty tree", nil, 0},
+		{"1 element tree", []int{1}, 1},
+		{"3 element tree", []int{1, 2, 3}, 1},
+		{"7 element tree", []int{1, 2, 3, 4, 5, 6, 7}, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rbt, err2 := ConstructFromValues(test.input...)
+			if err2 != nil {
+				t.Fatalf("ConstructFromValues() failed with error: %v", err2)
+			}
+
+			got, err2 := rbt.BlackHeight()
+			if err2 != nil {
+				t.Fatalf("BlackHeight() failed with error: %v", err2)
+			} else if got != test.want {
+				t.Errorf("BlackHeight() returned incorrect results, want: %v, got: %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTraversals(t *testing.T) {
+	var rbt *RedBlackTree[int]
+	for _, traverse := range []func() (string, error){rbt.TraverseBFS, rbt.TraverseDFSInOrder, rbt.TraverseDFSPreOrder, rbt.TraverseDFSPostOrder} {
+		_, err := traverse()
+		if !errors.Is(err, treeNilError) {
+			t.Errorf("traversal on a nil tree should have failed with error: %v, got: %v", treeNilError, err)
+		}
+	}
+
+	// 2B(1B, 4R(3B, 6B(5R, 7R)))
+	rbt, err := ConstructFromValues(1, 2, 3, 4, 5, 6, 7)
+	if err != nil {
+		t.Fatalf("ConstructFromValues() failed with error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		traverse func() (string, error)
+		want     string
+		seq      []int
+		wantSeq  []int
+	}{
+		{"BFS", rbt.TraverseBFS, "-(2)--(1)--(4)--(3)--(6)--(5)--(7)-", slices.Collect(rbt.BFS()), []int{2, 1, 4, 3, 6, 5, 7}},
+		{"InOrder", rbt.TraverseDFSInOrder, "-(1)--(2)--(3)--(4)--(5)--(6)--(7)-", slices.Collect(rbt.InOrder()), []int{1, 2, 3, 4, 5, 6, 7}},
+		{"PreOrder", rbt.TraverseDFSPreOrder, "-(2)--(1)--(4)--(3)--(6)--(5)--(7)-", slices.Collect(rbt.PreOrder()), []int{2, 1, 4, 3, 6, 5, 7}},
+		{"PostOrder", rbt.TraverseDFSPostOrder, "-(1)--(3)--(5)--(7)--(6)--(4)--(2)-", slices.Collect(rbt.PostOrder()), []int{1, 3, 5, 7, 6, 4, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err2 := test.traverse()
+			if err2 != nil {
+				t.Fatalf("traversal failed with error: %v", err2)
+			} else if got != test.want {
+				t.Errorf("traversal returned incorrect results, want: %v, got: %v", test.want, got)
+			}
+
+			if !slices.Equal(test.seq, test.wantSeq) {
+				t.Errorf("iterator returned incorrect results, want: %v, got: %v", test.wantSeq, test.seq)
+			}
+		})
+	}
+
+	all := slices.Collect(rbt.All())
+	if !slices.Equal(all, []int{1, 2, 3, 4, 5, 6, 7}) {
+		t.Errorf("All() returned incorrect results, want: %v, got: %v", []int{1, 2, 3, 4, 5, 6, 7}, all)
+	}
+}